@@ -8,8 +8,11 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // database zona waktu tertanam, untuk server tanpa tzdata (misal Windows atau image minimal)
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-co-op/gocron/v2"
+	bolt "go.etcd.io/bbolt"
 
 	"discord-ai-tech-news/config"
 	botPkg "discord-ai-tech-news/internal/bot"
@@ -28,26 +31,105 @@ func main() {
 		port = "8080"
 	}
 
+	// Database lokal untuk riwayat artikel
+	db, err := repository.OpenBoltDB(cfg.DataPath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %s", err)
+	}
+	defer db.Close()
+
+	historyRepo, err := repository.NewBoltHistoryRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize article history: %s", err)
+	}
+
+	guildConfigRepo, err := repository.NewBoltGuildConfigRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize guild config: %s", err)
+	}
+
+	scheduleRepo, err := repository.NewBoltScheduleRepository(db, service.DefaultSchedules)
+	if err != nil {
+		log.Fatalf("Failed to initialize schedules: %s", err)
+	}
+
+	watchRepo, err := repository.NewBoltWatchRepository(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize watchlists: %s", err)
+	}
+
 	// Build dependencies dari luar ke dalam
-	newsRepo := repository.NewNewsApiRepository()
+	newsRepo, stopCachePruning := buildNewsCache(cfg, db, buildNewsRepository(cfg))
+	// Dijalankan sebelum db.Close (defer LIFO) agar prune yang sedang berjalan selesai dulu
+	defer stopCachePruning()
 	newsService := service.NewExternalNewsService(newsRepo)
-	messageUsecase := usecase.NewMessageUsecase(newsService)
+	guildConfigService := service.NewGuildConfigService(guildConfigRepo, scheduleRepo)
+	cronService := service.NewCronService(newsService, historyRepo, guildConfigService, scheduleRepo, service.CronOptions{
+		RepostWindow: cfg.RepostWindow,
+		Location:     cfg.Location,
+		CatchUpGrace: cfg.CatchUpGrace,
+		MinInterval:  cfg.MinInterval,
+		Elector:      buildElector(cfg),
+		Retention:    cfg.Retention,
+	})
+	digestService := service.NewDigestService(historyRepo)
+	watchService := service.NewWatchService(watchRepo, newsService, service.RateLimit(cfg.WatchSearch))
+	commandLimiter := service.NewRateLimiter(map[string]service.RateLimit{
+		service.RateScopeUser:    service.RateLimit(cfg.RateLimits.User),
+		service.RateScopeChannel: service.RateLimit(cfg.RateLimits.Channel),
+		service.RateScopeGuild:   service.RateLimit(cfg.RateLimits.Guild),
+	})
+	messageUsecase := usecase.NewMessageUsecase(newsService, guildConfigService, cronService, digestService, watchService, usecase.NewPaginationStore(cfg.PageTTL), commandLimiter)
 	messageHandler := discordHandler.NewMessageHandler(messageUsecase)
+	interactionHandler := discordHandler.NewInteractionHandler(messageUsecase)
 
 	// Initialize Discord bot first
-	bot := botPkg.NewDiscordBot(cfg.DiscordToken, messageHandler)
+	bot := botPkg.NewDiscordBot(cfg.DiscordToken, cfg.ApplicationID, cfg.CommandGuildID, messageHandler, interactionHandler)
 	defer bot.Close()
 
-	// Initialize cron service dengan Discord bot
-	cronService := service.NewCronService(newsService, bot)
+	// Health check dipakai bersama oleh /health, /readyz dan command /status
+	health := service.NewHealthService(bot, cronService, newsService, repository.NewBoltPinger(db))
+	messageUsecase.SetHealth(health)
 
-	if err := cronService.Start(); err != nil {
+	// Start cron service dengan Discord bot
+	if err := cronService.Start(bot); err != nil {
 		log.Fatalf("Failed to start cron service: %s", err)
 	}
 
+	// Polling watchlist, DM dikirim lewat bot
+	watchService.Start(bot)
+	if err := cronService.AddIntervalJob("watch-poll", cfg.WatchInterval, watchService.Poll); err != nil {
+		log.Fatalf("Failed to schedule watchlist polling: %s", err)
+	}
+
+	// Keepalive opsional untuk host gratis; default off
+	keepalive, err := service.NewKeepalive(service.KeepaliveOptions{
+		Mode:     cfg.Keepalive.Mode,
+		URL:      cfg.Keepalive.URL,
+		Interval: cfg.Keepalive.Interval,
+	})
+	if err != nil {
+		log.Fatalf("Invalid keepalive config: %s", err)
+	}
+	if keepalive != nil {
+		keepalive.Start()
+		defer keepalive.Stop()
+	}
+
 	// Start Gin HTTP server
 	router := gin.Default()
-	httpHandler.RegisterRoutes(router)
+	// Tanpa TRUSTED_PROXIES, X-Forwarded-For diabaikan agar IP client tidak bisa dipalsukan
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %s", err)
+	}
+	httpHandler.RegisterRoutes(router, httpHandler.Dependencies{
+		CronService: cronService,
+		Health:      health,
+		AdminToken:  cfg.AdminToken,
+		RateLimiter: service.NewRateLimiter(map[string]service.RateLimit{
+			service.RateScopeClient: service.RateLimit(cfg.RateLimits.HTTP),
+		}),
+	})
 
 	srv := &http.Server{
 		Addr:    ":" + port,
@@ -76,3 +158,79 @@ func main() {
 	defer cancel()
 	srv.Shutdown(ctx)
 }
+
+// buildElector membuat leader lease jika LEADER_LEASE_FILE diisi, agar hanya satu
+// replica yang menjalankan job berita. nil berarti instance tunggal.
+func buildElector(cfg *config.Config) gocron.Elector {
+	if cfg.LeaseFile == "" {
+		return nil
+	}
+
+	lease, err := repository.NewFileLease(cfg.LeaseFile, cfg.InstanceID, cfg.LeaseTTL)
+	if err != nil {
+		log.Fatalf("Failed to initialize leader lease: %s", err)
+	}
+
+	// Lease diperpanjang di background; dilepas oleh CronService.Stop
+	lease.StartRenewal()
+
+	log.Printf("🔒 Leader election enabled: instance %s, lease %s (ttl %s)", cfg.InstanceID, cfg.LeaseFile, cfg.LeaseTTL)
+	return lease
+}
+
+// buildNewsCache membungkus repository berita dengan cache TTL; NEWS_CACHE_TTL=0 menonaktifkannya.
+// Fungsi yang dikembalikan menghentikan pruning cache persisten dan harus dipanggil
+// sebelum database ditutup.
+func buildNewsCache(cfg *config.Config, db *bolt.DB, repo repository.NewsRepository) (repository.NewsRepository, func()) {
+	if cfg.NewsCache.TTL <= 0 {
+		return repo, func() {}
+	}
+
+	var store repository.NewsCacheStore
+	stopPruning := func() {}
+	if cfg.NewsCache.Persist {
+		boltStore, err := repository.NewBoltNewsCacheStore(db)
+		if err != nil {
+			log.Fatalf("Failed to initialize news cache: %s", err)
+		}
+		// Entry tidak pernah hidup lebih lama dari TTL, jadi pruning per TTL cukup
+		// untuk menjaga ukuran bucket
+		boltStore.StartPruning(max(cfg.NewsCache.TTL, time.Minute))
+		store, stopPruning = boltStore, boltStore.StopPruning
+	}
+
+	log.Printf("📦 News cache enabled: ttl %s, %d entries, persistent: %t", cfg.NewsCache.TTL, cfg.NewsCache.Size, cfg.NewsCache.Persist)
+	return repository.NewCachedRepository(repo, cfg.NewsCache.TTL, cfg.NewsCache.Size, store), stopPruning
+}
+
+// buildNewsRepository menggabungkan semua sumber dari NEWS_SOURCES ke dalam satu aggregate repository
+func buildNewsRepository(cfg *config.Config) repository.NewsRepository {
+	if cfg.DemoMode {
+		log.Printf("🧪 DEMO_MODE enabled: serving mock news, external sources are disabled")
+		return repository.NewMockNewsRepository()
+	}
+
+	var sources []repository.WeightedSource
+	for _, source := range cfg.NewsSources {
+		var repo repository.NewsRepository
+		switch source.Name {
+		case "newsapi":
+			repo = repository.NewNewsApiRepository()
+		case "rss":
+			repo = repository.NewRSSRepository(cfg.RSSFeeds)
+		case "hackernews":
+			repo = repository.NewHackerNewsRepository()
+		default:
+			log.Fatalf("Unknown news source %q (expected newsapi, rss or hackernews)", source.Name)
+		}
+
+		log.Printf("📡 News source enabled: %s (weight %.2f)", source.Name, source.Weight)
+		sources = append(sources, repository.WeightedSource{
+			Name:       source.Name,
+			Repository: repo,
+			Weight:     source.Weight,
+		})
+	}
+
+	return repository.NewAggregateRepository(sources)
+}
@@ -1,16 +1,78 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DiscordToken string
-	NewsAPIKey   string
-	AppPort      string
+	DiscordToken   string
+	ApplicationID  string
+	CommandGuildID string
+	NewsAPIKey     string
+	AppPort        string
+	NewsSources    []SourceConfig
+	RSSFeeds       []string
+	DemoMode       bool
+	DataPath       string
+	RepostWindow   time.Duration
+	PageTTL        time.Duration
+	AdminToken     string
+	Location       *time.Location
+	CatchUpGrace   time.Duration
+	MinInterval    time.Duration
+	LeaseFile      string
+	LeaseTTL       time.Duration
+	InstanceID     string
+	Retention      time.Duration
+	WatchInterval  time.Duration
+	WatchSearch    RateLimitConfig
+	ServerURL      string
+	TrustedProxies []string
+	Keepalive      KeepaliveConfig
+	RateLimits     RateLimitsConfig
+	NewsCache      NewsCacheConfig
+}
+
+// NewsCacheConfig mengatur cache hasil sumber berita; TTL 0 menonaktifkan cache
+type NewsCacheConfig struct {
+	TTL     time.Duration
+	Size    int
+	Persist bool
+}
+
+// RateLimitConfig adalah Limit permintaan per Period; Limit 0 menonaktifkan limit
+type RateLimitConfig struct {
+	Limit  int
+	Period time.Duration
+}
+
+// RateLimitsConfig mengatur rate limit command Discord per user, channel dan guild,
+// serta request HTTP per IP client
+type RateLimitsConfig struct {
+	User    RateLimitConfig
+	Channel RateLimitConfig
+	Guild   RateLimitConfig
+	HTTP    RateLimitConfig
+}
+
+// KeepaliveConfig mengatur ping berkala untuk host yang menidurkan service tanpa traffic
+type KeepaliveConfig struct {
+	Mode     string
+	URL      string
+	Interval time.Duration
+}
+
+// SourceConfig adalah satu sumber berita dari NEWS_SOURCES beserta bobotnya
+type SourceConfig struct {
+	Name   string
+	Weight float64
 }
 
 func Load() *Config {
@@ -25,8 +87,23 @@ func Load() *Config {
 	}
 
 	newsAPIKey := os.Getenv("NEWS_API_KEY")
-	if newsAPIKey == "" {
-		log.Fatal("NEWS_API_KEY is not set in environment variables")
+
+	// NEWS_SOURCES: daftar "nama:bobot", misal "newsapi:1.0,hackernews:1.2,rss:0.8"
+	newsSources := parseSources(os.Getenv("NEWS_SOURCES"))
+	if len(newsSources) == 0 {
+		newsSources = []SourceConfig{{Name: "hackernews", Weight: 1}, {Name: "rss", Weight: 1}}
+		if newsAPIKey != "" {
+			newsSources = append([]SourceConfig{{Name: "newsapi", Weight: 1}}, newsSources...)
+		}
+	}
+
+	// DEMO_MODE=true menyajikan berita contoh tanpa memanggil sumber eksternal
+	demoMode, _ := strconv.ParseBool(os.Getenv("DEMO_MODE"))
+
+	for _, source := range newsSources {
+		if source.Name == "newsapi" && newsAPIKey == "" && !demoMode {
+			log.Fatal("NEWS_API_KEY is not set in environment variables")
+		}
 	}
 
 	appPort := os.Getenv("APP_PORT")
@@ -34,11 +111,210 @@ func Load() *Config {
 		appPort = "8080"
 	}
 
+	// DATA_PATH: lokasi database lokal untuk riwayat artikel
+	dataPath := os.Getenv("DATA_PATH")
+	if dataPath == "" {
+		dataPath = "data/news.db"
+	}
+
+	// REPOST_WINDOW: artikel yang sudah dikirim ke channel dalam window ini tidak dikirim ulang
+	repostWindow := parseDuration("REPOST_WINDOW", 72*time.Hour)
+
+	// PAGINATION_TTL: berapa lama tombol Previous/Next tetap aktif sejak terakhir dipakai
+	pageTTL := parseDuration("PAGINATION_TTL", 10*time.Minute)
+
+	// DEFAULT_TIMEZONE: zona waktu IANA untuk jadwal yang tidak menentukan zonanya sendiri
+	timezone := os.Getenv("DEFAULT_TIMEZONE")
+	if timezone == "" {
+		timezone = "Asia/Jakarta"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Fatalf("DEFAULT_TIMEZONE %q is not a valid IANA timezone: %v", timezone, err)
+	}
+
+	// CATCHUP_GRACE: jadwal yang terlewat saat bot mati dalam window ini dikirim susulan; 0 menonaktifkan
+	catchUpGrace := parseOptionalDuration("CATCHUP_GRACE", 6*time.Hour)
+
+	// SCHEDULE_MIN_INTERVAL: jarak minimal antar run jadwal baru, agar jadwal seperti
+	// "* * * * *" tidak menghabiskan kuota sumber berita atau membanjiri channel
+	minInterval := parseDuration("SCHEDULE_MIN_INTERVAL", time.Hour)
+
+	// HISTORY_RETENTION: riwayat artikel yang lebih tua dari ini dihapus; minimal 31 hari
+	// agar digest bulanan tetap punya data lengkap
+	retention := parseDuration("HISTORY_RETENTION", 45*24*time.Hour)
+	if retention < 31*24*time.Hour {
+		log.Printf("Warning: HISTORY_RETENTION %s is shorter than the monthly digest window, using 744h", retention)
+		retention = 31 * 24 * time.Hour
+	}
+
+	// SERVER_URL: URL publik server, dipakai oleh KEEPALIVE_MODE=self
+	serverURL := os.Getenv("SERVER_URL")
+	if serverURL == "" {
+		serverURL = "http://localhost:" + appPort
+	}
+
+	// KEEPALIVE_MODE: off (default), self (ping SERVER_URL/livez) atau url (ping KEEPALIVE_URL)
+	keepaliveMode := strings.ToLower(strings.TrimSpace(os.Getenv("KEEPALIVE_MODE")))
+	if keepaliveMode == "" {
+		keepaliveMode = "off"
+	}
+	keepaliveURL := os.Getenv("KEEPALIVE_URL")
+	if keepaliveMode == "self" {
+		keepaliveURL = serverURL
+	}
+
+	// NEWS_CACHE_TTL: berapa lama hasil fetch/search disimpan sebelum sumber berita dipanggil lagi; 0 menonaktifkan
+	newsCacheTTL := parseOptionalDuration("NEWS_CACHE_TTL", 5*time.Minute)
+	newsCacheSize, err := strconv.Atoi(os.Getenv("NEWS_CACHE_SIZE"))
+	if err != nil || newsCacheSize <= 0 {
+		newsCacheSize = 200
+	}
+	// NEWS_CACHE_PERSIST=true menyimpan cache di DATA_PATH agar tetap terisi setelah restart
+	newsCachePersist, _ := strconv.ParseBool(os.Getenv("NEWS_CACHE_PERSIST"))
+
+	// WATCH_SEARCH_LIMIT: jatah request sumber berita polling watchlist (berita terbaru dan
+	// SearchNews), format sama dengan RATE_LIMIT_*.
+	// Default menyisakan sebagian besar kuota harian NewsAPI (100 request) untuk jadwal dan command.
+	watchSearch := parseRateLimit("WATCH_SEARCH_LIMIT", RateLimitConfig{Limit: 20, Period: 24 * time.Hour})
+
+	// INSTANCE_ID: identitas replica untuk leader lease, default hostname-pid
+	instanceID := os.Getenv("INSTANCE_ID")
+	if instanceID == "" {
+		hostname, _ := os.Hostname()
+		instanceID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
 	return &Config{
-		DiscordToken: discordToken,
-		NewsAPIKey:   newsAPIKey,
-		AppPort:      appPort,
+		DiscordToken:   discordToken,
+		ApplicationID:  os.Getenv("APPLICATION_ID"),
+		CommandGuildID: os.Getenv("COMMAND_GUILD_ID"),
+		NewsAPIKey:     newsAPIKey,
+		AppPort:        appPort,
+		NewsSources:    newsSources,
+		RSSFeeds:       splitList(os.Getenv("RSS_FEEDS")),
+		DemoMode:       demoMode,
+		DataPath:       dataPath,
+		RepostWindow:   repostWindow,
+		PageTTL:        pageTTL,
+		AdminToken:     os.Getenv("ADMIN_TOKEN"),
+		Location:       location,
+		CatchUpGrace:   catchUpGrace,
+		MinInterval:    minInterval,
+		LeaseFile:      os.Getenv("LEADER_LEASE_FILE"),
+		LeaseTTL:       parseDuration("LEADER_LEASE_TTL", 2*time.Minute),
+		InstanceID:     instanceID,
+		Retention:      retention,
+		WatchInterval:  parseDuration("WATCH_INTERVAL", 15*time.Minute),
+		WatchSearch:    watchSearch,
+		ServerURL:      serverURL,
+		// TRUSTED_PROXIES: IP/CIDR reverse proxy yang boleh mengisi X-Forwarded-For.
+		// Kosong berarti header tersebut diabaikan dan rate limit HTTP memakai IP koneksi.
+		TrustedProxies: splitList(os.Getenv("TRUSTED_PROXIES")),
+		Keepalive: KeepaliveConfig{
+			Mode:     keepaliveMode,
+			URL:      keepaliveURL,
+			Interval: parseDuration("KEEPALIVE_INTERVAL", 10*time.Minute),
+		},
+		// RATE_LIMIT_*: format "jumlah/periode", misal "5/1m"; "0" menonaktifkan.
+		// Default per guild mengikuti kuota NewsAPI paket gratis (100 request/hari):
+		// 3 jadwal harian, sampai 3 catch-up setelah restart, dan polling watchlist
+		// yang (dengan WATCH_INTERVAL 15m, 96 kali sehari) dibatasi WATCH_SEARCH_LIMIT
+		// 20 request, menyisakan 74. Dengan 20 per server, tiga server yang ramai
+		// masih muat dalam kuota.
+		RateLimits: RateLimitsConfig{
+			User:    parseRateLimit("RATE_LIMIT_USER", RateLimitConfig{Limit: 5, Period: time.Minute}),
+			Channel: parseRateLimit("RATE_LIMIT_CHANNEL", RateLimitConfig{Limit: 10, Period: time.Minute}),
+			Guild:   parseRateLimit("RATE_LIMIT_GUILD", RateLimitConfig{Limit: 20, Period: 24 * time.Hour}),
+			HTTP:    parseRateLimit("RATE_LIMIT_HTTP", RateLimitConfig{Limit: 60, Period: time.Minute}),
+		},
+		NewsCache: NewsCacheConfig{
+			TTL:     newsCacheTTL,
+			Size:    newsCacheSize,
+			Persist: newsCachePersist,
+		},
+	}
+}
+
+// splitList memecah nilai env yang dipisahkan koma dan membuang entry kosong
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseDuration membaca durasi Go (misal "72h") dari env, atau fallback jika kosong/tidak valid
+func parseDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Warning: invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// parseOptionalDuration seperti parseDuration, tetapi durasi nol ("0", "0s", "0m")
+// diterima dan berarti fitur tersebut dinonaktifkan
+func parseOptionalDuration(key string, fallback time.Duration) time.Duration {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("Warning: invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// parseRateLimit membaca format "jumlah/periode" (misal "5/1m") dari env, atau
+// fallback jika kosong/tidak valid. "0" menonaktifkan limit.
+func parseRateLimit(key string, fallback RateLimitConfig) RateLimitConfig {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return fallback
+	}
+	if value == "0" {
+		return RateLimitConfig{}
+	}
+
+	limitStr, periodStr, _ := strings.Cut(value, "/")
+	limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+	period, periodErr := time.ParseDuration(strings.TrimSpace(periodStr))
+	if err != nil || periodErr != nil || limit < 0 || period <= 0 {
+		log.Printf("Warning: invalid %s %q (expected e.g. 5/1m), using %d/%s", key, value, fallback.Limit, fallback.Period)
+		return fallback
+	}
+	return RateLimitConfig{Limit: limit, Period: period}
+}
+
+// parseSources membaca format "nama:bobot"; bobot yang kosong atau tidak valid dianggap 1
+func parseSources(value string) []SourceConfig {
+	var sources []SourceConfig
+	for _, item := range splitList(value) {
+		name, weightStr, _ := strings.Cut(item, ":")
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+		if err != nil || weight <= 0 {
+			weight = 1
+		}
+		sources = append(sources, SourceConfig{
+			Name:   strings.ToLower(strings.TrimSpace(name)),
+			Weight: weight,
+		})
 	}
+	return sources
 }
 
 func LoadEnv() {
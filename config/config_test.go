@@ -0,0 +1,85 @@
+package config
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseOptionalDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 6 * time.Hour},
+		{"0", 0},
+		{"0s", 0},
+		{"0m", 0},
+		{"90m", 90 * time.Minute},
+		{"-1h", 6 * time.Hour},
+		{"soon", 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("TEST_GRACE", tt.value)
+		if got := parseOptionalDuration("TEST_GRACE", 6*time.Hour); got != tt.want {
+			t.Errorf("parseOptionalDuration(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	fallback := RateLimitConfig{Limit: 30, Period: 24 * time.Hour}
+	tests := []struct {
+		value string
+		want  RateLimitConfig
+	}{
+		{"", fallback},
+		{"0", RateLimitConfig{}},
+		{"5/1m", RateLimitConfig{Limit: 5, Period: time.Minute}},
+		{" 10 / 1h ", RateLimitConfig{Limit: 10, Period: time.Hour}},
+		{"5", fallback},
+		{"-1/1m", fallback},
+		{"5/0s", fallback},
+		{"many/1m", fallback},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("TEST_RATE_LIMIT", tt.value)
+		if got := parseRateLimit("TEST_RATE_LIMIT", fallback); got != tt.want {
+			t.Errorf("parseRateLimit(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultsFitNewsAPIQuota(t *testing.T) {
+	const (
+		newsAPIDailyQuota = 100
+		scheduledPosts    = 3 // morning, afternoon dan evening; digest default di-pause
+		catchUps          = 3 // semua jadwal harian bisa dikirim susulan setelah restart
+		busyGuilds        = 3
+	)
+
+	t.Setenv("TOKEN", "test-token")
+	for _, key := range []string{"WATCH_INTERVAL", "WATCH_SEARCH_LIMIT", "RATE_LIMIT_GUILD"} {
+		t.Setenv(key, "")
+	}
+	cfg := Load()
+
+	perDay := func(limit RateLimitConfig) int {
+		if limit.Limit <= 0 {
+			return 0
+		}
+		return int(math.Ceil(float64(limit.Limit) * float64(24*time.Hour) / float64(limit.Period)))
+	}
+
+	// Setiap request polling watchlist memakai jatah WATCH_SEARCH_LIMIT, jadi jumlahnya
+	// tidak melebihi jatah tersebut maupun jumlah polling per hari
+	watch := min(int(24*time.Hour/cfg.WatchInterval), perDay(cfg.WatchSearch))
+
+	guild := perDay(cfg.RateLimits.Guild)
+	if total := scheduledPosts + catchUps + watch + busyGuilds*guild; total > newsAPIDailyQuota {
+		t.Errorf("default daily requests = %d (%d scheduled, %d catch-up, %d watch, %d busy servers x %d), exceeds the NewsAPI quota of %d",
+			total, scheduledPosts, catchUps, watch, busyGuilds, guild, newsAPIDailyQuota)
+	}
+}
@@ -3,19 +3,33 @@ package bot
 import (
 	"fmt"
 	"log"
+	"time"
+
+	"discord-ai-tech-news/internal/response"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 type MessageHandler interface {
 	HandleMessage(s *discordgo.Session, m *discordgo.MessageCreate)
+	HandleGuildCreate(s *discordgo.Session, g *discordgo.GuildCreate)
+	HandleReactionAdd(s *discordgo.Session, r *discordgo.MessageReactionAdd)
+	HandleReactionRemove(s *discordgo.Session, r *discordgo.MessageReactionRemove)
+}
+
+type InteractionHandler interface {
+	Commands() []*discordgo.ApplicationCommand
+	HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate)
 }
 
 type DiscordBot struct {
 	session *discordgo.Session
 }
 
-func NewDiscordBot(token string, handler MessageHandler) *DiscordBot {
+// NewDiscordBot membuka koneksi gateway dan mendaftarkan slash command.
+// Jika guildID diisi, command didaftarkan ke guild tersebut saja (langsung aktif);
+// jika kosong, didaftarkan global (bisa butuh waktu hingga satu jam untuk muncul).
+func NewDiscordBot(token, applicationID, guildID string, handler MessageHandler, interactionHandler InteractionHandler) *DiscordBot {
 	dg, err := discordgo.New("Bot " + token)
 	if err != nil {
 		log.Fatalf("Failed to create Discord session: %v", err)
@@ -25,6 +39,10 @@ func NewDiscordBot(token string, handler MessageHandler) *DiscordBot {
 
 	// Use injected handler instead of hard-coded one
 	dg.AddHandler(handler.HandleMessage)
+	dg.AddHandler(handler.HandleGuildCreate)
+	dg.AddHandler(handler.HandleReactionAdd)
+	dg.AddHandler(handler.HandleReactionRemove)
+	dg.AddHandler(interactionHandler.HandleInteraction)
 
 	if err = dg.Open(); err != nil {
 		log.Fatalf("Failed to open Discord connection: %v", err)
@@ -32,9 +50,54 @@ func NewDiscordBot(token string, handler MessageHandler) *DiscordBot {
 
 	log.Println("Discord bot connected successfully!")
 
-	return &DiscordBot{
+	bot := &DiscordBot{
 		session: dg,
 	}
+
+	if applicationID == "" {
+		applicationID = dg.State.User.ID
+	}
+	if err := bot.RegisterCommands(applicationID, guildID, interactionHandler.Commands()); err != nil {
+		log.Printf("⚠️ Failed to register slash commands: %v", err)
+	}
+
+	return bot
+}
+
+// RegisterCommands menimpa seluruh slash command milik aplikasi dengan daftar yang diberikan
+func (bot *DiscordBot) RegisterCommands(applicationID, guildID string, commands []*discordgo.ApplicationCommand) error {
+	registered, err := bot.session.ApplicationCommandBulkOverwrite(applicationID, guildID, commands)
+	if err != nil {
+		return fmt.Errorf("failed to register application commands: %v", err)
+	}
+
+	log.Printf("✅ Registered %d slash commands", len(registered))
+	return nil
+}
+
+// SendDirectMessage mengirim DM ke user dan mengembalikan ID pesan yang terkirim
+func (bot *DiscordBot) SendDirectMessage(userID string, message *response.DiscordMessage) (string, error) {
+	channel, err := bot.session.UserChannelCreate(userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to open DM channel with user %s: %v", userID, err)
+	}
+	return bot.SendMessage(channel.ID, message)
+}
+
+// GatewayReady melaporkan apakah koneksi gateway Discord sedang tersambung
+// dan sudah menerima event READY
+func (bot *DiscordBot) GatewayReady() bool {
+	bot.session.RLock()
+	defer bot.session.RUnlock()
+	return bot.session.DataReady
+}
+
+// Heartbeat mengembalikan latency heartbeat gateway terakhir dan waktu ACK terakhir
+// dari Discord
+func (bot *DiscordBot) Heartbeat() (time.Duration, time.Time) {
+	bot.session.RLock()
+	defer bot.session.RUnlock()
+	return bot.session.LastHeartbeatAck.Sub(bot.session.LastHeartbeatSent), bot.session.LastHeartbeatAck
 }
 
 // Close method untuk graceful shutdown
@@ -42,20 +105,55 @@ func (bot *DiscordBot) Close() error {
 	return bot.session.Close()
 }
 
-// SendNewsToChannel mengirim pesan berita ke channel tertentu
-func (bot *DiscordBot) SendNewsToChannel(channelName string, message string) error {
-	// Cari channel berdasarkan nama
+// GuildIDs mengembalikan ID semua guild tempat bot bergabung. State dibaca di bawah
+// read lock karena handler GUILD_CREATE/GUILD_DELETE mengubahnya dari goroutine gateway.
+func (bot *DiscordBot) GuildIDs() []string {
+	bot.session.State.RLock()
+	defer bot.session.State.RUnlock()
+
+	guildIDs := make([]string, 0, len(bot.session.State.Guilds))
 	for _, guild := range bot.session.State.Guilds {
-		for _, channel := range guild.Channels {
-			if channel.Name == channelName && channel.Type == discordgo.ChannelTypeGuildText {
-				_, err := bot.session.ChannelMessageSend(channel.ID, message)
-				if err != nil {
-					return fmt.Errorf("failed to send message to channel %s: %v", channelName, err)
-				}
-				return nil
-			}
+		guildIDs = append(guildIDs, guild.ID)
+	}
+	return guildIDs
+}
+
+// FindChannelID mencari ID text channel berdasarkan nama di dalam satu guild
+func (bot *DiscordBot) FindChannelID(guildID string, channelName string) (string, error) {
+	guild, err := bot.session.State.Guild(guildID)
+	if err != nil {
+		return "", fmt.Errorf("guild %s not found: %v", guildID, err)
+	}
+
+	bot.session.State.RLock()
+	defer bot.session.State.RUnlock()
+	for _, channel := range guild.Channels {
+		if channel.Name == channelName && channel.Type == discordgo.ChannelTypeGuildText {
+			return channel.ID, nil
 		}
 	}
 
-	return fmt.Errorf("channel %s not found", channelName)
+	return "", fmt.Errorf("channel %s not found in guild %s", channelName, guildID)
+}
+
+// ChannelGuildID mengembalikan ID guild pemilik channel, dari state atau REST API
+// jika channel belum ada di state
+func (bot *DiscordBot) ChannelGuildID(channelID string) (string, error) {
+	channel, err := bot.session.State.Channel(channelID)
+	if err != nil {
+		if channel, err = bot.session.Channel(channelID); err != nil {
+			return "", fmt.Errorf("channel %s not found: %v", channelID, err)
+		}
+	}
+	return channel.GuildID, nil
+}
+
+// SendMessage mengirim pesan (teks dan/atau embed) ke channel berdasarkan ID
+// dan mengembalikan ID pesan yang terkirim
+func (bot *DiscordBot) SendMessage(channelID string, message *response.DiscordMessage) (string, error) {
+	sent, err := bot.session.ChannelMessageSendComplex(channelID, message.ToMessageSend())
+	if err != nil {
+		return "", fmt.Errorf("failed to send message to channel %s: %v", channelID, err)
+	}
+	return sent.ID, nil
 }
@@ -0,0 +1,317 @@
+package discord
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"discord-ai-tech-news/internal/response"
+	"discord-ai-tech-news/internal/usecase"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// InteractionHandler menangani slash command (application command) Discord
+type InteractionHandler struct {
+	usecase *usecase.MessageUsecase
+}
+
+func NewInteractionHandler(usecase *usecase.MessageUsecase) *InteractionHandler {
+	return &InteractionHandler{
+		usecase: usecase,
+	}
+}
+
+// Commands membuat definisi slash command dari command registry. Command biasa
+// mendapat option string dari CommandArg dan setiap CommandFlag; command dengan
+// Subcommands mendapat subcommand beserta option-nya.
+func (h *InteractionHandler) Commands() []*discordgo.ApplicationCommand {
+	var commands []*discordgo.ApplicationCommand
+	for _, cmd := range h.usecase.Commands() {
+		command := &discordgo.ApplicationCommand{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+		}
+		if cmd.AdminOnly {
+			command.DefaultMemberPermissions = &manageServerPermission
+			command.DMPermission = &dmDisabled
+		}
+		if cmd.Arg != nil {
+			command.Options = append(command.Options, stringOption(cmd.Arg.Name, cmd.Arg.Description, cmd.Arg.Required))
+		}
+		for _, flag := range cmd.Flags {
+			command.Options = append(command.Options, flagOption(flag))
+		}
+		for _, subcommand := range cmd.Subcommands {
+			command.Options = append(command.Options, subcommandOption(subcommand))
+		}
+		commands = append(commands, command)
+	}
+	return commands
+}
+
+var (
+	manageServerPermission int64 = discordgo.PermissionManageServer
+	dmDisabled                   = false
+)
+
+func flagOption(flag usecase.CommandFlag) *discordgo.ApplicationCommandOption {
+	if flag.Type == usecase.OptionInteger {
+		minValue := float64(flag.MinValue)
+		return &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        flag.Name,
+			Description: flag.Description,
+			MinValue:    &minValue,
+			MaxValue:    float64(flag.MaxValue),
+		}
+	}
+
+	option := stringOption(flag.Name, flag.Description, false)
+	for _, choice := range flag.Choices {
+		option.Choices = append(option.Choices, &discordgo.ApplicationCommandOptionChoice{Name: choice, Value: choice})
+	}
+	return option
+}
+
+func subcommandOption(subcommand usecase.Subcommand) *discordgo.ApplicationCommandOption {
+	option := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        subcommand.Name,
+		Description: subcommand.Description,
+	}
+	for _, subOption := range subcommand.Options {
+		option.Options = append(option.Options, commandOption(subOption))
+	}
+	return option
+}
+
+func commandOption(option usecase.CommandOption) *discordgo.ApplicationCommandOption {
+	if option.Type == usecase.OptionChannel {
+		return &discordgo.ApplicationCommandOption{
+			Type:         discordgo.ApplicationCommandOptionChannel,
+			Name:         option.Name,
+			Description:  option.Description,
+			ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+			Required:     option.Required,
+		}
+	}
+
+	result := stringOption(option.Name, option.Description, option.Required)
+	for _, choice := range option.Choices {
+		result.Choices = append(result.Choices, &discordgo.ApplicationCommandOptionChoice{Name: choice.Name, Value: choice.Value})
+	}
+	return result
+}
+
+func stringOption(name, description string, required bool) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        name,
+		Description: description,
+		Required:    required,
+	}
+}
+
+func (h *InteractionHandler) HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		h.handleCommand(s, i)
+	case discordgo.InteractionMessageComponent:
+		h.handleComponent(s, i)
+	}
+}
+
+func (h *InteractionHandler) handleCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	cmd, ok := h.usecase.LookupCommand(data.Name)
+
+	// Command admin hanya untuk member dengan izin Manage Server
+	if ok && cmd.AdminOnly {
+		if reply := forbiddenReply(i); reply != nil {
+			respondEphemeral(s, i, reply, "/"+data.Name)
+			return
+		}
+	}
+
+	user := interactionUser(i)
+	req := usecase.CommandRequest{
+		UserID:    user.ID,
+		Username:  user.Username,
+		GuildID:   i.GuildID,
+		ChannelID: i.ChannelID,
+	}
+
+	options := data.Options
+	if len(options) == 1 && options[0].Type == discordgo.ApplicationCommandOptionSubCommand {
+		req.Subcommand = options[0].Name
+		options = options[0].Options
+	}
+	for _, option := range options {
+		var value string
+		switch option.Type {
+		case discordgo.ApplicationCommandOptionString:
+			value = option.StringValue()
+		case discordgo.ApplicationCommandOptionChannel:
+			value, _ = option.Value.(string)
+		case discordgo.ApplicationCommandOptionInteger:
+			value = strconv.FormatInt(option.IntValue(), 10)
+		default:
+			continue
+		}
+
+		if ok && cmd.Arg != nil && option.Name == cmd.Arg.Name {
+			req.Args = value
+			continue
+		}
+		if req.Options == nil {
+			req.Options = make(map[string]string)
+		}
+		req.Options[option.Name] = value
+	}
+
+	log.Printf("User %s used slash command: /%s %s", user.Username, strings.TrimSpace(data.Name+" "+req.Subcommand), req.Args)
+
+	h.runCommand(s, i, data.Name, req, cmd)
+}
+
+// runCommand menjalankan command dari registry dan membalas interaction dengan
+// hasilnya; cmd nil jika command tidak dikenal. Pemanggil yang terkena rate limit
+// mendapat balasan cooldown ephemeral.
+func (h *InteractionHandler) runCommand(s *discordgo.Session, i *discordgo.InteractionCreate, name string, req usecase.CommandRequest, cmd *usecase.Command) {
+	if reply, _ := h.usecase.Throttle(cmd, req); reply != nil {
+		respondEphemeral(s, i, reply, "/"+name)
+		return
+	}
+
+	// Fetch berita bisa lebih dari 3 detik, jadi balas dengan deferred response dulu
+	deferred := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}
+	if cmd != nil && cmd.Ephemeral {
+		deferred.Data = &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral}
+	}
+	if err := s.InteractionRespond(i.Interaction, deferred); err != nil {
+		log.Printf("Failed to defer interaction response: %v", err)
+		return
+	}
+
+	reply, err := h.usecase.RunCommand(context.Background(), name, req)
+	if err != nil {
+		log.Printf("Error processing command /%s: %v", name, err)
+	}
+	if reply == nil {
+		reply = response.TextMessage(systemErrorMessage)
+	}
+
+	edit := &discordgo.WebhookEdit{
+		Content:    &reply.Content,
+		Embeds:     &reply.Embeds,
+		Components: &reply.Components,
+	}
+	sent, err := s.InteractionResponseEdit(i.Interaction, edit)
+	if err != nil {
+		log.Printf("Failed to send interaction response: %v", err)
+		return
+	}
+
+	h.usecase.TrackPagination(sent.ID, reply)
+}
+
+// forbiddenReply mengembalikan pesan penolakan jika pemanggil tidak punya izin Manage Server
+func forbiddenReply(i *discordgo.InteractionCreate) *response.DiscordMessage {
+	if i.Member == nil || i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+		return response.TextMessage("⛔ Command ini hanya untuk admin server (Manage Server).")
+	}
+	return nil
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, reply *response.DiscordMessage, command string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: reply.Content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to respond to %s: %v", command, err)
+	}
+}
+
+// handleComponent menangani klik tombol Previous/Next pada pesan berhalaman dan
+// tombol saran command dari pesan "command tidak dikenal"
+func (h *InteractionHandler) handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	if name, args, ok := response.ParseRunCommandID(customID); ok {
+		h.handleRunButton(s, i, name, args)
+		return
+	}
+
+	var delta int
+	switch customID {
+	case response.PagePrevButtonID:
+		delta = -1
+	case response.PageNextButtonID:
+		delta = 1
+	default:
+		return
+	}
+
+	reply := h.usecase.TurnPage(i.Message.ID, delta)
+	if reply == nil {
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "⌛ Navigasi halaman sudah kedaluwarsa. Jalankan command-nya lagi untuk hasil terbaru.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to respond to expired pagination: %v", err)
+		}
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    reply.Content,
+			Embeds:     reply.Embeds,
+			Components: reply.Components,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to update paginated message: %v", err)
+	}
+}
+
+// handleRunButton menjalankan command yang disarankan sebagai pesan baru atas nama
+// user yang mengklik tombol
+func (h *InteractionHandler) handleRunButton(s *discordgo.Session, i *discordgo.InteractionCreate, name, args string) {
+	user := interactionUser(i)
+	req := usecase.CommandRequest{
+		Args:      args,
+		UserID:    user.ID,
+		Username:  user.Username,
+		GuildID:   i.GuildID,
+		ChannelID: i.ChannelID,
+	}
+
+	log.Printf("User %s ran suggested command: /%s %s", user.Username, name, args)
+
+	cmd, _ := h.usecase.LookupCommand(name)
+	h.runCommand(s, i, name, req, cmd)
+}
+
+// interactionUser mengembalikan user pemanggil, baik dari guild (Member) maupun DM (User)
+func interactionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	if i.User != nil {
+		return i.User
+	}
+	return &discordgo.User{}
+}
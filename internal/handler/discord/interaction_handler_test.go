@@ -0,0 +1,80 @@
+package discord
+
+import (
+	"testing"
+
+	"discord-ai-tech-news/internal/usecase"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestCommandsBuildAdminSubcommandsFromRegistry(t *testing.T) {
+	h := NewInteractionHandler(usecase.NewMessageUsecase(nil, nil, nil, nil, nil, nil, nil))
+
+	commands := make(map[string]*discordgo.ApplicationCommand)
+	for _, command := range h.Commands() {
+		commands[command.Name] = command
+	}
+
+	config := commands["config"]
+	if config == nil {
+		t.Fatal("/config is not registered")
+	}
+	if config.DefaultMemberPermissions == nil || *config.DefaultMemberPermissions != discordgo.PermissionManageServer {
+		t.Error("/config should require Manage Server")
+	}
+	if config.DMPermission == nil || *config.DMPermission {
+		t.Error("/config should be disabled in DMs")
+	}
+
+	subcommands := make(map[string]*discordgo.ApplicationCommandOption)
+	for _, option := range config.Options {
+		if option.Type != discordgo.ApplicationCommandOptionSubCommand {
+			t.Fatalf("/config option %q is not a subcommand", option.Name)
+		}
+		subcommands[option.Name] = option
+	}
+	addChannel := subcommands[usecase.ConfigActionAddNewsChannel]
+	if addChannel == nil || len(addChannel.Options) != 1 || addChannel.Options[0].Type != discordgo.ApplicationCommandOptionChannel {
+		t.Errorf("add-news-channel = %+v, want one channel option", addChannel)
+	}
+
+	var kind *discordgo.ApplicationCommandOption
+	for _, option := range commands["schedule"].Options {
+		if option.Name != usecase.ScheduleActionAdd {
+			continue
+		}
+		for _, addOption := range option.Options {
+			if addOption.Name == "kind" {
+				kind = addOption
+			}
+		}
+	}
+	if kind == nil || len(kind.Choices) != 3 || kind.Choices[0].Value != "news" {
+		t.Errorf("schedule add kind = %+v, want three choices starting with news", kind)
+	}
+
+	if news := commands["news"]; news.DefaultMemberPermissions != nil {
+		t.Error("/news should be available to everyone")
+	}
+}
+
+func TestCommandsRegisterLimitAsInteger(t *testing.T) {
+	h := NewInteractionHandler(usecase.NewMessageUsecase(nil, nil, nil, nil, nil, nil, nil))
+
+	for _, command := range h.Commands() {
+		if command.Name != "search" {
+			continue
+		}
+		for _, option := range command.Options {
+			if option.Name != usecase.FlagLimit {
+				continue
+			}
+			if option.Type != discordgo.ApplicationCommandOptionInteger || option.MinValue == nil || *option.MinValue != 1 || option.MaxValue != 50 {
+				t.Errorf("limit = %+v, want an integer option between 1 and 50", option)
+			}
+			return
+		}
+	}
+	t.Fatal("/search has no limit option")
+}
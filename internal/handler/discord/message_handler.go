@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"discord-ai-tech-news/internal/response"
 	"discord-ai-tech-news/internal/usecase"
 
 	"github.com/bwmarrin/discordgo"
@@ -28,6 +29,8 @@ func OnMessageCreate(s *discordgo.Session, m *discordgo.MessageCreate) {
 	usecase.HandleDiscordMessage(s, m)
 }
 
+const systemErrorMessage = "❌ **Terjadi kesalahan sistem**\n\n🔄 Silakan coba lagi dalam beberapa saat."
+
 type MessageHandler struct {
 	usecase *usecase.MessageUsecase
 }
@@ -56,30 +59,62 @@ func (h *MessageHandler) HandleMessage(s *discordgo.Session, m *discordgo.Messag
 		return
 	}
 
-	// Only respond in specific channel
-	if channel.Name != "🔥┃ai-tech-news" && channel.Name != "🕹️┃dev-talk" {
+	// Only respond in the guild's configured command channels
+	if !h.usecase.CommandChannelAllowed(m.GuildID, channel.ID, channel.Name) {
 		return
 	}
 
 	// Process the message
 	ctx := context.Background()
-	response, err := h.usecase.ProcessMessage(ctx, m.Content)
-
-	if response == "" && err == nil {
+	reply, err := h.usecase.ProcessMessage(ctx, m.Content, usecase.CommandRequest{
+		UserID:      m.Author.ID,
+		Username:    m.Author.Username,
+		GuildID:     m.GuildID,
+		ChannelID:   channel.ID,
+		ChannelName: channel.Name,
+	})
+
+	if reply == nil && err == nil {
 		return
 	}
 
 	if err != nil {
 		log.Printf("Error processing message from %s: %v", m.Author.Username, err)
-		response = "❌ **Terjadi kesalahan sistem**\n\n🔄 Silakan coba lagi dalam beberapa saat."
+		if reply == nil {
+			reply = response.TextMessage(systemErrorMessage)
+		}
 	}
 
 	// Log the interaction
 	log.Printf("User %s (%s) sent: %s", m.Author.Username, m.Author.ID, m.Content)
 
 	// Send response
-	_, err = s.ChannelMessageSend(m.ChannelID, response)
+	sent, err := s.ChannelMessageSendComplex(m.ChannelID, reply.ToMessageSend())
 	if err != nil {
 		log.Printf("Failed to send message: %v", err)
+		return
+	}
+
+	h.usecase.TrackPagination(sent.ID, reply)
+}
+
+// HandleGuildCreate dipanggil saat bot bergabung ke guild baru dan saat guild
+// tersedia kembali setelah koneksi gateway tersambung
+func (h *MessageHandler) HandleGuildCreate(s *discordgo.Session, g *discordgo.GuildCreate) {
+	h.usecase.GuildJoined(g.ID)
+}
+
+// HandleReactionAdd menghitung reaksi user pada pesan berita untuk ranking digest
+func (h *MessageHandler) HandleReactionAdd(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	if r.UserID == s.State.User.ID {
+		return
+	}
+	h.usecase.RecordReaction(r.MessageID, r.Emoji.Name, 1)
+}
+
+func (h *MessageHandler) HandleReactionRemove(s *discordgo.Session, r *discordgo.MessageReactionRemove) {
+	if r.UserID == s.State.User.ID {
+		return
 	}
+	h.usecase.RecordReaction(r.MessageID, r.Emoji.Name, -1)
 }
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"discord-ai-tech-news/internal/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth melindungi endpoint admin dengan header "Authorization: Bearer <ADMIN_TOKEN>".
+// Jika ADMIN_TOKEN kosong, semua endpoint admin dinonaktifkan.
+func AdminAuth(token string) gin.HandlerFunc {
+	jsonHandler := response.NewJSONHandler()
+
+	return func(c *gin.Context) {
+		if token == "" {
+			jsonHandler.Forbidden(c, "Admin API is disabled", "set ADMIN_TOKEN to enable it")
+			c.Abort()
+			return
+		}
+
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			jsonHandler.Unauthorized(c, "Invalid or missing admin token")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
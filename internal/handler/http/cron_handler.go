@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"discord-ai-tech-news/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const cronTimeLayout = "2006-01-02 15:04 MST"
+
+// CronHandler menyajikan status job gocron dan riwayat run dari CronService
+type CronHandler struct {
+	cron service.SchedulerStatus
+}
+
+func NewCronHandler(cron service.SchedulerStatus) *CronHandler {
+	return &CronHandler{cron: cron}
+}
+
+func (h *CronHandler) Register(r *gin.RouterGroup) {
+	r.GET("/health/cron", h.status)
+	r.GET("/health/cron/history", h.history)
+}
+
+// status menampilkan jadwal berikutnya di zona server dan zona target, beserta hasil run terakhir.
+// Data yang sama dirender oleh command /cron di Discord.
+func (h *CronHandler) status(c *gin.Context) {
+	serverZone, _ := time.Now().Zone()
+	cronJobs := gin.H{}
+	jobs := []gin.H{}
+
+	for _, status := range h.cron.JobStatuses() {
+		nextRunServer := status.NextRun.In(time.Local).Format(cronTimeLayout)
+		nextRunTarget := status.NextRun.In(status.Location).Format(cronTimeLayout)
+
+		summary := nextRunTarget + " (" + nextRunServer + " server)"
+		if status.LastResult != nil {
+			summary += " • last: " + status.LastResult.Summary()
+		}
+		cronJobs[status.Name+" ("+status.Location.String()+")"] = summary
+
+		job := gin.H{
+			"id":              status.JobID,
+			"schedule_id":     status.ScheduleID,
+			"name":            status.Name,
+			"cron":            status.CronExpr,
+			"timezone":        status.Location.String(),
+			"next_run_server": nextRunServer,
+			"next_run_target": nextRunTarget,
+		}
+		if !status.LastRun.IsZero() {
+			job["last_run"] = status.LastRun.In(status.Location).Format(cronTimeLayout)
+		}
+		if status.LastResult != nil {
+			job["last_result"] = runJSON(*status.LastResult)
+		}
+		jobs = append(jobs, job)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":          h.cron.State(),
+		"cron_jobs":       cronJobs,
+		"jobs":            jobs,
+		"server_timezone": time.Local.String() + " (" + serverZone + ")",
+		"timezone":        h.cron.DefaultLocation().String(),
+		"last_check":      time.Now().Format("2006-01-02 15:04:05 MST"),
+	})
+}
+
+// history mengembalikan riwayat run; query opsional: schedule=<id>, limit=<n>
+func (h *CronHandler) history(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 0 {
+		limit = 20
+	}
+
+	runs := []gin.H{}
+	for _, record := range h.cron.RunHistory(c.Query("schedule"), limit) {
+		runs = append(runs, runJSON(record))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"runs":  runs,
+		"count": len(runs),
+	})
+}
+
+func runJSON(record service.RunRecord) gin.H {
+	run := gin.H{
+		"schedule_id":     record.ScheduleID,
+		"name":            record.Name,
+		"timezone":        record.Timezone,
+		"started_at":      record.StartedAt.Format(time.RFC3339),
+		"duration":        record.Duration.Round(time.Millisecond).String(),
+		"success":         record.Success,
+		"channels":        record.Channels,
+		"articles_posted": record.ArticlesPosted,
+		"catch_up":        record.CatchUp,
+	}
+	if record.Error != "" {
+		run["error"] = record.Error
+	}
+	return run
+}
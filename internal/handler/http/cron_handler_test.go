@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeSchedulerStatus struct {
+	state string
+	runs  []service.RunRecord
+}
+
+func (f fakeSchedulerStatus) State() string { return f.state }
+
+func (f fakeSchedulerStatus) JobStatuses() []service.JobStatus {
+	return []service.JobStatus{{JobID: "job-1", ScheduleID: "morning", Name: "morning", CronExpr: "0 8 * * *", Location: time.UTC, NextRun: time.Now().Add(time.Hour)}}
+}
+
+func (f fakeSchedulerStatus) RunHistory(scheduleID string, limit int) []service.RunRecord {
+	if limit > 0 && len(f.runs) > limit {
+		return f.runs[:limit]
+	}
+	return f.runs
+}
+
+func (f fakeSchedulerStatus) DefaultLocation() *time.Location { return time.UTC }
+
+func serveCron(t *testing.T, status service.SchedulerStatus, path string) map[string]any {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewCronHandler(status).Register(router.Group(""))
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d", path, recorder.Code)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+	return body
+}
+
+func TestCronStatusReportsSchedulerState(t *testing.T) {
+	for _, state := range []string{service.SchedulerRunning, service.SchedulerStopped, service.SchedulerNotLeader} {
+		body := serveCron(t, fakeSchedulerStatus{state: state}, "/health/cron")
+		if body["status"] != state {
+			t.Errorf("status = %v, want %s", body["status"], state)
+		}
+		if jobs, _ := body["jobs"].([]any); len(jobs) != 1 {
+			t.Errorf("jobs = %v, want one job", body["jobs"])
+		}
+	}
+}
+
+func TestCronHistoryLimit(t *testing.T) {
+	status := fakeSchedulerStatus{runs: []service.RunRecord{{Name: "a"}, {Name: "b"}, {Name: "c"}}}
+
+	if body := serveCron(t, status, "/health/cron/history?limit=2"); body["count"] != float64(2) {
+		t.Errorf("count = %v, want 2", body["count"])
+	}
+	if body := serveCron(t, status, "/health/cron/history?limit=x"); body["count"] != float64(3) {
+		t.Errorf("invalid limit count = %v, want the default limit", body["count"])
+	}
+}
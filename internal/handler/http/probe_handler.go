@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"discord-ai-tech-news/internal/response"
+	"discord-ai-tech-news/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProbeHandler menyajikan health per komponen serta liveness dan readiness probe
+// untuk orchestrator atau monitor uptime
+type ProbeHandler struct {
+	health *service.HealthService
+	json   *response.JSONHandler
+}
+
+func NewProbeHandler(health *service.HealthService) *ProbeHandler {
+	return &ProbeHandler{
+		health: health,
+		json:   response.NewJSONHandler(),
+	}
+}
+
+func (h *ProbeHandler) Register(r *gin.Engine) {
+	r.GET("/health", h.status)
+	r.GET("/livez", h.live)
+	r.GET("/readyz", h.ready)
+}
+
+// status melaporkan kondisi setiap komponen; 503 jika ada yang tidak sehat
+func (h *ProbeHandler) status(c *gin.Context) {
+	h.json.HealthResponse(c, h.health.Health())
+}
+
+// live hanya memastikan proses dan HTTP server merespons; tidak memeriksa dependensi
+// agar restart tidak dipicu oleh gangguan Discord atau sumber berita
+func (h *ProbeHandler) live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "alive"})
+}
+
+// ready mengembalikan 503 jika gateway Discord terputus, scheduler berhenti
+// atau fetch berita terakhir gagal
+func (h *ProbeHandler) ready(c *gin.Context) {
+	checks, ready := h.health.Readiness()
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "not_ready", http.StatusServiceUnavailable
+	}
+
+	c.JSON(code, gin.H{
+		"status":    status,
+		"checks":    checks,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeGatewayStatus struct{ ready bool }
+
+func (f fakeGatewayStatus) GatewayReady() bool { return f.ready }
+
+func (f fakeGatewayStatus) Heartbeat() (time.Duration, time.Time) {
+	return 40 * time.Millisecond, time.Now()
+}
+
+type fakeRunning bool
+
+func (f fakeRunning) Running() bool { return bool(f) }
+
+type fakeFetchReporter struct{ status service.FetchStatus }
+
+func (f fakeFetchReporter) LastFetch() service.FetchStatus { return f.status }
+
+type fakePinger struct{ err error }
+
+func (f fakePinger) Ping() error { return f.err }
+
+func healthyProbes() *service.HealthService {
+	return service.NewHealthService(fakeGatewayStatus{ready: true}, fakeRunning(true), fakeFetchReporter{}, fakePinger{})
+}
+
+func serveProbe(t *testing.T, health *service.HealthService, path string) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewProbeHandler(health).Register(router)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var body map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+	return recorder.Code, body
+}
+
+func TestProbesWhenHealthy(t *testing.T) {
+	health := healthyProbes()
+
+	for path, want := range map[string]string{"/health": "healthy", "/readyz": "ready", "/livez": "alive"} {
+		code, body := serveProbe(t, health, path)
+		if code != http.StatusOK || body["status"] != want {
+			t.Errorf("GET %s = %d %v, want 200 %s", path, code, body["status"], want)
+		}
+	}
+}
+
+func TestProbesWhenDegraded(t *testing.T) {
+	cases := map[string]*service.HealthService{
+		"discord_gateway": service.NewHealthService(fakeGatewayStatus{ready: false}, fakeRunning(true), fakeFetchReporter{}, fakePinger{}),
+		"scheduler":       service.NewHealthService(fakeGatewayStatus{ready: true}, fakeRunning(false), fakeFetchReporter{}, fakePinger{}),
+		"news_source": service.NewHealthService(fakeGatewayStatus{ready: true}, fakeRunning(true), fakeFetchReporter{status: service.FetchStatus{
+			At:       time.Now(),
+			Error:    "upstream timeout",
+			Failures: 5,
+		}}, fakePinger{}),
+		"storage": service.NewHealthService(fakeGatewayStatus{ready: true}, fakeRunning(true), fakeFetchReporter{}, fakePinger{err: errors.New("database not open")}),
+	}
+
+	for component, health := range cases {
+		code, body := serveProbe(t, health, "/health")
+		if code != http.StatusServiceUnavailable || body["status"] != service.StatusDegraded {
+			t.Errorf("%s down: GET /health = %d %v, want 503 degraded", component, code, body["status"])
+		}
+		services, _ := body["services"].(map[string]any)
+		if info, _ := services[component].(map[string]any); info["status"] != service.ServiceUnhealthy {
+			t.Errorf("%s down: component status = %v, want unhealthy", component, info["status"])
+		}
+
+		code, body = serveProbe(t, health, "/readyz")
+		if code != http.StatusServiceUnavailable || body["status"] != "not_ready" {
+			t.Errorf("%s down: GET /readyz = %d %v, want 503 not_ready", component, code, body["status"])
+		}
+		checks, _ := body["checks"].([]any)
+		for _, check := range checks {
+			check, _ := check.(map[string]any)
+			if healthy := check["name"] != component; check["healthy"] != healthy {
+				t.Errorf("%s down: readiness check %v healthy = %v", component, check["name"], check["healthy"])
+			}
+		}
+
+		// Liveness tidak bergantung pada dependensi
+		if code, _ := serveProbe(t, health, "/livez"); code != http.StatusOK {
+			t.Errorf("%s down: GET /livez = %d, want 200", component, code)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"discord-ai-tech-news/internal/response"
+	"discord-ai-tech-news/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit membatasi request per IP client dengan token bucket. Request yang
+// melewati limit dibalas 429 beserta header Retry-After.
+func RateLimit(limiter *service.RateLimiter) gin.HandlerFunc {
+	jsonHandler := response.NewJSONHandler()
+
+	return func(c *gin.Context) {
+		decision := limiter.Allow(service.RateKey{Scope: service.RateScopeClient, ID: c.ClientIP()})
+		if !decision.Allowed {
+			jsonHandler.RateLimitError(c, decision.RetryAfter)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
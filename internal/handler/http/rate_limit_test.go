@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitedRouter(t *testing.T, trustedProxies []string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		t.Fatalf("SetTrustedProxies: %v", err)
+	}
+	limiter := service.NewRateLimiter(map[string]service.RateLimit{
+		service.RateScopeClient: {Limit: 2, Period: time.Minute},
+	})
+	router.GET("/", RateLimit(limiter), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func request(router *gin.Engine, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	router := newRateLimitedRouter(t, nil)
+
+	// X-Forwarded-For yang berbeda tidak memberi bucket baru tanpa proxy tepercaya
+	for i, forwardedFor := range []string{"1.1.1.1", "2.2.2.2"} {
+		if code := request(router, "203.0.113.7:4000", forwardedFor).Code; code != http.StatusOK {
+			t.Fatalf("request %d = %d, want 200", i, code)
+		}
+	}
+	recorder := request(router, "203.0.113.7:4000", "3.3.3.3")
+	if recorder.Code != http.StatusTooManyRequests {
+		t.Fatalf("spoofed request = %d, want 429", recorder.Code)
+	}
+	if recorder.Header().Get("Retry-After") != "30" {
+		t.Errorf("Retry-After = %q, want 30", recorder.Header().Get("Retry-After"))
+	}
+
+	if code := request(router, "198.51.100.9:4000", "").Code; code != http.StatusOK {
+		t.Errorf("other client = %d, want 200", code)
+	}
+}
+
+func TestRateLimitUsesForwardedForFromTrustedProxy(t *testing.T) {
+	router := newRateLimitedRouter(t, []string{"10.0.0.0/8"})
+
+	for i := 0; i < 2; i++ {
+		request(router, "10.0.0.2:4000", "1.1.1.1")
+	}
+	if code := request(router, "10.0.0.2:4000", "1.1.1.1").Code; code != http.StatusTooManyRequests {
+		t.Errorf("third request from the same client = %d, want 429", code)
+	}
+	if code := request(router, "10.0.0.2:4000", "2.2.2.2").Code; code != http.StatusOK {
+		t.Errorf("other client behind the proxy = %d, want 200", code)
+	}
+}
@@ -4,44 +4,48 @@ import (
 	"net/http"
 	"time"
 
+	"discord-ai-tech-news/internal/service"
+
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterRoutes(r *gin.Engine) {
-	r.GET("/", func(c *gin.Context) {
+// Dependencies berisi service yang dipakai oleh route HTTP
+type Dependencies struct {
+	CronService *service.CronService
+	Health      *service.HealthService
+	AdminToken  string
+	RateLimiter *service.RateLimiter
+}
+
+func RegisterRoutes(r *gin.Engine, deps Dependencies) {
+	// Health per komponen, liveness dan readiness probe; tidak di-rate limit agar
+	// orchestrator dan monitor tidak pernah mendapat 429
+	NewProbeHandler(deps.Health).Register(r)
+
+	// Route lain dibatasi per IP client
+	api := r.Group("", RateLimit(deps.RateLimiter))
+
+	api.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "Discord AI Tech News Bot API", "status": "running"})
 	})
 
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy", "bot": "online"})
-	})
+	// Health check untuk cron jobs: status job dan riwayat run
+	NewCronHandler(deps.CronService).Register(api)
 
-	// Health check untuk cron jobs
-	r.GET("/health/cron", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status": "running",
-			"cron_jobs": gin.H{
-				"morning_news":   "08:00 WIB (02:00 Frankfurt)",
-				"afternoon_news": "13:00 WIB (07:00 Frankfurt)",
-				"evening_news":   "17:00 WIB (11:00 Frankfurt)",
-				"service_health": "every minute",
-			},
-			"deployment_timezone": "Frankfurt (GMT+1/GMT+2)",
-			"target_timezone":     "Asia/Jakarta (GMT+7/WIB)",
-			"timezone_note":       "Cron times adjusted for GMT+7 target from Frankfurt deployment",
-			"last_check":          time.Now().Format("2006-01-02 15:04:05 MST"),
-		})
-	})
+	// Admin API, dilindungi ADMIN_TOKEN
+	admin := api.Group("/admin", AdminAuth(deps.AdminToken))
+	NewScheduleHandler(deps.CronService).Register(admin)
 
-	r.POST("/webhook", func(c *gin.Context) {
+	api.POST("/webhook", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"message": "webhook received"})
 	})
 
-	r.POST("/start", func(c *gin.Context) {
+	// Endpoint lama yang dulu di-ping setiap menit; dipertahankan untuk monitor eksternal
+	api.POST("/start", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message":   "Service start triggered",
 			"status":    "success",
-			"timestamp": time.Now().Format("2006-01-02 15:04:05 WIB"),
+			"timestamp": time.Now().Format("2006-01-02 15:04:05 MST"),
 		})
 	})
 }
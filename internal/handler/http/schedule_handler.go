@@ -0,0 +1,118 @@
+package http
+
+import (
+	"errors"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+	"discord-ai-tech-news/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleHandler menyediakan endpoint admin untuk mengelola jadwal berita otomatis
+type ScheduleHandler struct {
+	cron service.ScheduleManager
+	json *response.JSONHandler
+}
+
+func NewScheduleHandler(cron service.ScheduleManager) *ScheduleHandler {
+	return &ScheduleHandler{
+		cron: cron,
+		json: response.NewJSONHandler(),
+	}
+}
+
+// scheduleRequest adalah body JSON untuk POST /admin/schedules
+type scheduleRequest struct {
+	Name      string `json:"name" binding:"required"`
+	CronExpr  string `json:"cron" binding:"required"`
+	Timezone  string `json:"timezone"`
+	GuildID   string `json:"guild_id"`
+	ChannelID string `json:"channel_id"`
+	Header    string `json:"header"`
+	Query     string `json:"query"`
+	Kind      string `json:"kind"`
+}
+
+func (h *ScheduleHandler) Register(r *gin.RouterGroup) {
+	r.GET("/schedules", h.list)
+	r.POST("/schedules", h.add)
+	r.DELETE("/schedules/:id", h.remove)
+	r.POST("/schedules/:id/pause", h.pause)
+	r.POST("/schedules/:id/resume", h.resume)
+}
+
+func (h *ScheduleHandler) list(c *gin.Context) {
+	schedules, err := h.cron.ListSchedules()
+	if err != nil {
+		h.json.InternalServerError(c, "Failed to load schedules", err.Error())
+		return
+	}
+	h.json.Success(c, schedules)
+}
+
+func (h *ScheduleHandler) add(c *gin.Context) {
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.json.BadRequest(c, "Invalid schedule payload", err.Error())
+		return
+	}
+
+	schedule, err := h.cron.AddSchedule(repository.Schedule{
+		Name:      req.Name,
+		CronExpr:  req.CronExpr,
+		Timezone:  req.Timezone,
+		GuildID:   req.GuildID,
+		ChannelID: req.ChannelID,
+		Header:    req.Header,
+		Query:     req.Query,
+		Kind:      req.Kind,
+	})
+	if err != nil {
+		h.scheduleError(c, err)
+		return
+	}
+	h.json.Success(c, schedule, "Schedule created")
+}
+
+func (h *ScheduleHandler) remove(c *gin.Context) {
+	schedule, err := h.cron.RemoveSchedule(c.Param("id"))
+	if err != nil {
+		h.scheduleError(c, err)
+		return
+	}
+	h.json.Success(c, schedule, "Schedule removed")
+}
+
+func (h *ScheduleHandler) pause(c *gin.Context) {
+	schedule, err := h.cron.PauseSchedule(c.Param("id"))
+	if err != nil {
+		h.scheduleError(c, err)
+		return
+	}
+	h.json.Success(c, schedule, "Schedule paused")
+}
+
+func (h *ScheduleHandler) resume(c *gin.Context) {
+	schedule, err := h.cron.ResumeSchedule(c.Param("id"))
+	if err != nil {
+		h.scheduleError(c, err)
+		return
+	}
+	h.json.Success(c, schedule, "Schedule resumed")
+}
+
+func (h *ScheduleHandler) scheduleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrScheduleNotFound):
+		h.json.NotFound(c, "Schedule not found")
+	case errors.Is(err, service.ErrInvalidSchedule):
+		h.json.BadRequest(c, "Invalid schedule", err.Error())
+	case errors.Is(err, repository.ErrNotLeader):
+		// Replica lain memegang lease; perubahan di sini tidak akan dijalankan leader
+		h.json.ServiceUnavailable(c, "This replica is not the leader, send schedule changes to the leader", err.Error())
+	default:
+		h.json.InternalServerError(c, "Failed to update schedule", err.Error())
+	}
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WeightedSource adalah satu sumber berita beserta bobotnya. Bobot yang lebih
+// besar membuat artikel dari sumber tersebut muncul lebih awal.
+type WeightedSource struct {
+	Name       string
+	Repository NewsRepository
+	Weight     float64
+}
+
+// SourceMatcher diimplementasikan sumber yang memuat artikel dari beberapa
+// penerbit, misal RSS, agar pencarian --source bisa diarahkan ke sumber itu saja.
+type SourceMatcher interface {
+	MatchesSource(source string) bool
+}
+
+// SourceResult mencatat hasil satu sumber pada fetch terakhir
+type SourceResult struct {
+	Name     string        `json:"name"`
+	Success  bool          `json:"success"`
+	Count    int           `json:"count"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// AggregateRepository fans out every request to all configured sources
+// concurrently and merges the results into one ranked list. A failing source
+// is skipped; an error is only returned when every source fails.
+type AggregateRepository struct {
+	sources []WeightedSource
+
+	mu          sync.RWMutex
+	lastResults []SourceResult
+}
+
+func NewAggregateRepository(sources []WeightedSource) *AggregateRepository {
+	for i := range sources {
+		if sources[i].Weight <= 0 {
+			sources[i].Weight = 1
+		}
+	}
+
+	return &AggregateRepository{
+		sources: sources,
+	}
+}
+
+func (r *AggregateRepository) GetLatestNews() ([]News, error) {
+	since := time.Now().Add(-24 * time.Hour)
+	return r.GetLatestNewsSince(since)
+}
+
+func (r *AggregateRepository) GetLatestNewsSince(since time.Time) ([]News, error) {
+	return r.fanOut(r.sources, func(repo NewsRepository) ([]News, error) {
+		return repo.GetLatestNewsSince(since)
+	})
+}
+
+// SearchNews hanya menanyai sumber yang diminta lewat opts.Source, jadi
+// --source tidak menghabiskan kuota sumber lain. Nama dari NEWS_SOURCES (misal
+// "hackernews") memilih sumber itu apa adanya; nama penerbit (misal "wired")
+// diarahkan ke sumber yang memuatnya dan hasilnya disaring per artikel. Jika
+// tidak ada sumber yang memuat penerbit tersebut, semua sumber ditanyai.
+func (r *AggregateRepository) SearchNews(opts SearchOptions) ([]News, error) {
+	sources, filter := r.route(opts.Source)
+
+	news, err := r.fanOut(sources, func(repo NewsRepository) ([]News, error) {
+		return repo.SearchNews(opts)
+	})
+	if err != nil || !filter {
+		return news, err
+	}
+
+	var filtered []News
+	for _, article := range news {
+		if SourceMatches(article.Source, opts.Source) {
+			filtered = append(filtered, article)
+		}
+	}
+	return filtered, nil
+}
+
+// route memilih sumber untuk --source. filter bernilai true jika hasilnya
+// masih perlu disaring berdasarkan penerbit artikel.
+func (r *AggregateRepository) route(source string) ([]WeightedSource, bool) {
+	if source == "" {
+		return r.sources, false
+	}
+
+	for _, candidate := range r.sources {
+		if normalizeSource(candidate.Name) == normalizeSource(source) {
+			return []WeightedSource{candidate}, false
+		}
+	}
+
+	var routed []WeightedSource
+	for _, candidate := range r.sources {
+		if matcher, ok := candidate.Repository.(SourceMatcher); ok && matcher.MatchesSource(source) {
+			routed = append(routed, candidate)
+		}
+	}
+	if len(routed) == 0 {
+		log.Printf("🔀 [AGGREGATE] No source carries %q, searching all sources", source)
+		return r.sources, true
+	}
+	return routed, true
+}
+
+// LastResults mengembalikan status tiap sumber dari fetch terakhir
+func (r *AggregateRepository) LastResults() []SourceResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	results := make([]SourceResult, len(r.lastResults))
+	copy(results, r.lastResults)
+	return results
+}
+
+type weightedNews struct {
+	news News
+	rank float64
+}
+
+func (r *AggregateRepository) fanOut(sources []WeightedSource, fetch func(repo NewsRepository) ([]News, error)) ([]News, error) {
+	results := make([]SourceResult, len(sources))
+	batches := make([][]News, len(sources))
+	errs := make([]error, len(sources))
+
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source WeightedSource) {
+			defer wg.Done()
+
+			start := time.Now()
+			news, err := fetch(source.Repository)
+
+			results[i] = SourceResult{
+				Name:     source.Name,
+				Success:  err == nil,
+				Count:    len(news),
+				Duration: time.Since(start),
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+				errs[i] = fmt.Errorf("%s: %w", source.Name, err)
+				log.Printf("⚠️ [AGGREGATE] Source %s failed: %v", source.Name, err)
+				return
+			}
+			batches[i] = news
+		}(i, source)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	r.lastResults = results
+	r.mu.Unlock()
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+	log.Printf("📊 [AGGREGATE] %d/%d sources succeeded", succeeded, len(sources))
+
+	if succeeded == 0 && len(sources) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return merge(sources, batches), nil
+}
+
+// merge menggabungkan hasil semua sumber, membuang URL duplikat (artikel dari
+// sumber dengan bobot lebih tinggi yang dipertahankan) lalu mengurutkan
+// berdasarkan bobot sumber dikali kesegaran artikel.
+func merge(sources []WeightedSource, batches [][]News) []News {
+	now := time.Now()
+	byURL := make(map[string]int)
+	var merged []weightedNews
+
+	for i, batch := range batches {
+		weight := sources[i].Weight
+		for _, article := range batch {
+			candidate := weightedNews{
+				news: article,
+				rank: weight * freshness(now, article.PublishedAt),
+			}
+
+			if idx, exists := byURL[article.URL]; exists {
+				if candidate.rank > merged[idx].rank {
+					merged[idx] = candidate
+				}
+				continue
+			}
+
+			byURL[article.URL] = len(merged)
+			merged = append(merged, candidate)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].rank > merged[j].rank
+	})
+
+	news := make([]News, len(merged))
+	for i, item := range merged {
+		news[i] = item.news
+	}
+	return news
+}
+
+// freshness bernilai 1 untuk artikel baru dan turun perlahan seiring umur artikel
+func freshness(now, publishedAt time.Time) float64 {
+	ageHours := now.Sub(publishedAt).Hours()
+	if ageHours < 0 {
+		ageHours = 0
+	}
+	return 1 / (1 + ageHours/24)
+}
+
+// SourceMatches mencocokkan nama sumber tanpa memperhatikan huruf besar dan
+// spasi, jadi "hackernews" cocok dengan "Hacker News".
+func SourceMatches(name, source string) bool {
+	want := normalizeSource(source)
+	return want != "" && strings.Contains(normalizeSource(name), want)
+}
+
+func normalizeSource(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))
+}
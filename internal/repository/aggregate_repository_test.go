@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubSource mencatat jumlah pencarian dan mengembalikan artikel tetap
+type stubSource struct {
+	mu         sync.Mutex
+	searches   int
+	news       []News
+	publishers []string
+}
+
+func (s *stubSource) GetLatestNews() ([]News, error) { return s.news, nil }
+
+func (s *stubSource) GetLatestNewsSince(time.Time) ([]News, error) { return s.news, nil }
+
+func (s *stubSource) SearchNews(SearchOptions) ([]News, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.searches++
+	return s.news, nil
+}
+
+func (s *stubSource) searchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.searches
+}
+
+// stubFeeds adalah stubSource yang memuat beberapa penerbit, seperti RSS
+type stubFeeds struct{ stubSource }
+
+func (s *stubFeeds) MatchesSource(source string) bool {
+	for _, publisher := range s.publishers {
+		if SourceMatches(publisher, source) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAggregateSearchRoutesSource(t *testing.T) {
+	now := time.Now()
+	newsapi := &stubSource{news: []News{{Title: "Wired on NewsAPI", URL: "https://a.com/1", Source: "Wired", PublishedAt: now}}}
+	hackernews := &stubSource{news: []News{{Title: "HN story", URL: "https://b.com/2", Source: "Hacker News", PublishedAt: now}}}
+	rss := &stubFeeds{stubSource{
+		news: []News{
+			{Title: "Wired feed", URL: "https://c.com/3", Source: "WIRED", PublishedAt: now},
+			{Title: "Verge feed", URL: "https://d.com/4", Source: "The Verge", PublishedAt: now},
+		},
+		publishers: []string{"www.wired.com", "www.theverge.com"},
+	}}
+	repo := NewAggregateRepository([]WeightedSource{
+		{Name: "newsapi", Repository: newsapi},
+		{Name: "hackernews", Repository: hackernews},
+		{Name: "rss", Repository: rss},
+	})
+
+	tests := []struct {
+		source    string
+		searched  []int // newsapi, hackernews, rss
+		wantTitle []string
+	}{
+		{source: "", searched: []int{1, 1, 1}, wantTitle: []string{"Wired on NewsAPI", "HN story", "Wired feed", "Verge feed"}},
+		{source: "HackerNews", searched: []int{0, 1, 0}, wantTitle: []string{"HN story"}},
+		{source: "newsapi", searched: []int{1, 0, 0}, wantTitle: []string{"Wired on NewsAPI"}},
+		{source: "the verge", searched: []int{0, 0, 1}, wantTitle: []string{"Verge feed"}},
+		{source: "engadget", searched: []int{1, 1, 1}, wantTitle: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			before := []int{newsapi.searchCount(), hackernews.searchCount(), rss.searchCount()}
+
+			news, err := repo.SearchNews(SearchOptions{Keyword: "x", Source: tt.source})
+			if err != nil {
+				t.Fatalf("SearchNews: %v", err)
+			}
+
+			after := []int{newsapi.searchCount(), hackernews.searchCount(), rss.searchCount()}
+			for i, want := range tt.searched {
+				if got := after[i] - before[i]; got != want {
+					t.Errorf("source %d searched %d times, want %d", i, got, want)
+				}
+			}
+
+			var titles []string
+			for _, article := range news {
+				titles = append(titles, article.Title)
+			}
+			if strings.Join(titles, "|") != strings.Join(tt.wantTitle, "|") {
+				t.Errorf("titles = %q, want %q", titles, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestRSSRepositorySearchOnlyFetchesMatchingFeeds(t *testing.T) {
+	var mu sync.Mutex
+	requested := make(map[string]int)
+	files := http.FileServer(http.Dir("testdata"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested[r.URL.Path]++
+		mu.Unlock()
+		files.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	// Host feed dibedakan lewat nama host yang mengarah ke server yang sama
+	verge := strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/verge.atom.xml"
+	repo := NewRSSRepository([]string{server.URL + "/techcrunch.rss.xml", verge})
+
+	if !repo.MatchesSource("localhost") || repo.MatchesSource("wired") {
+		t.Fatal("MatchesSource should match feed hosts only")
+	}
+	if _, err := repo.SearchNews(SearchOptions{Keyword: "e", Source: "localhost"}); err != nil {
+		t.Fatalf("SearchNews: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requested["/techcrunch.rss.xml"] != 0 || requested["/verge.atom.xml"] != 1 {
+		t.Errorf("requested = %v, want only the matching feed", requested)
+	}
+}
+
+func TestNewsAPISearchKeepsLanguageEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("language"); got != "de" {
+			t.Errorf("language param = %q, want de", got)
+		}
+		w.Write([]byte(`{"status":"ok","articles":[{"title":"KI","url":"https://a.com/1","source":{"name":"Heise"},"publishedAt":"2025-03-10T10:00:00Z"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	repo := &NewsApiRepository{client: server.Client(), baseURL: server.URL, apiKey: "test"}
+	news, err := repo.SearchNews(SearchOptions{Keyword: "KI", Language: "de"})
+	if err != nil {
+		t.Fatalf("SearchNews: %v", err)
+	}
+	if len(news) != 1 || news[0].Language != "" {
+		t.Errorf("news = %+v, want one article without a language", news)
+	}
+}
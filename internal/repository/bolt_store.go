@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// OpenBoltDB membuka (atau membuat) database bbolt lokal yang dipakai bersama
+// oleh semua repository persisten
+func OpenBoltDB(path string) (*bolt.DB, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create data directory %s: %w", dir, err)
+		}
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// BoltPinger memeriksa bahwa database bbolt masih terbuka dan bisa dibaca, untuk health check
+type BoltPinger struct {
+	db *bolt.DB
+}
+
+func NewBoltPinger(db *bolt.DB) *BoltPinger {
+	return &BoltPinger{db: db}
+}
+
+func (p *BoltPinger) Ping() error {
+	return p.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(articlesBucket) == nil {
+			return fmt.Errorf("bucket %s is missing", articlesBucket)
+		}
+		return nil
+	})
+}
+
+func putJSON(bucket *bolt.Bucket, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(key), data)
+}
+
+// getJSON mengembalikan false jika key tidak ditemukan
+func getJSON(bucket *bolt.Bucket, key string, value interface{}) (bool, error) {
+	data := bucket.Get([]byte(key))
+	if data == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, value)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// openTestDB membuka database bbolt baru di direktori sementara test
+func openTestDB(t *testing.T) *bolt.DB {
+	t.Helper()
+	db, err := OpenBoltDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("OpenBoltDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
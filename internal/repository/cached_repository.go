@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/sync/singleflight"
+)
+
+var newsCacheBucket = []byte("news_cache")
+
+// CacheReporter diimplementasikan CachedRepository: sama seperti NewsRepository,
+// ditambah keterangan apakah hasilnya berasal dari cache
+type CacheReporter interface {
+	GetLatestNewsSinceCached(since time.Time) (news []News, cacheHit bool, err error)
+	SearchNewsCached(opts SearchOptions) (news []News, cacheHit bool, err error)
+}
+
+// NewsCacheEntry adalah satu hasil fetch yang disimpan di cache
+type NewsCacheEntry struct {
+	News      []News    `json:"news"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewsCacheStore adalah penyimpanan persisten cache di belakang LRU memori, agar
+// cache tetap terisi setelah restart
+type NewsCacheStore interface {
+	// Get mengembalikan false jika key tidak ada atau sudah kedaluwarsa
+	Get(key string) (NewsCacheEntry, bool, error)
+	Put(key string, entry NewsCacheEntry) error
+}
+
+// CachedRepository adalah decorator NewsRepository dengan cache TTL: LRU di memori
+// dan opsional NewsCacheStore persisten. Permintaan identik yang datang bersamaan
+// digabung menjadi satu pemanggilan upstream. Hasil error tidak di-cache.
+type CachedRepository struct {
+	next     NewsRepository
+	ttl      time.Duration
+	capacity int
+	store    NewsCacheStore
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // depan = paling baru dipakai
+
+	group singleflight.Group
+}
+
+type cacheItem struct {
+	key   string
+	entry NewsCacheEntry
+}
+
+// NewCachedRepository membungkus next. store boleh nil untuk cache memori saja.
+func NewCachedRepository(next NewsRepository, ttl time.Duration, capacity int, store NewsCacheStore) *CachedRepository {
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	return &CachedRepository{
+		next:     next,
+		ttl:      ttl,
+		capacity: capacity,
+		store:    store,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+func (r *CachedRepository) GetLatestNews() ([]News, error) {
+	since := time.Now().Add(-24 * time.Hour)
+	return r.GetLatestNewsSince(since)
+}
+
+func (r *CachedRepository) GetLatestNewsSince(since time.Time) ([]News, error) {
+	news, _, err := r.GetLatestNewsSinceCached(since)
+	return news, err
+}
+
+func (r *CachedRepository) SearchNews(opts SearchOptions) ([]News, error) {
+	news, _, err := r.SearchNewsCached(opts)
+	return news, err
+}
+
+// GetLatestNewsSinceCached mengambil dari upstream sejak awal jam dari since, agar
+// pemanggilan "24 jam terakhir" yang berbeda beberapa menit memakai entry cache yang
+// sama, lalu membuang artikel sebelum since
+func (r *CachedRepository) GetLatestNewsSinceCached(since time.Time) ([]News, bool, error) {
+	from := since.Truncate(time.Hour)
+	key := "latest:" + strconv.FormatInt(from.Unix(), 10)
+
+	news, hit, err := r.get(key, func() ([]News, error) {
+		return r.next.GetLatestNewsSince(from)
+	})
+	return publishedSince(news, since), hit, err
+}
+
+// SearchNewsCached memakai key dari semua option yang memengaruhi hasil upstream;
+// Since dibulatkan per jam seperti GetLatestNewsSinceCached
+func (r *CachedRepository) SearchNewsCached(opts SearchOptions) ([]News, bool, error) {
+	since := opts.Since
+	if !since.IsZero() {
+		opts.Since = since.Truncate(time.Hour)
+	}
+
+	key := fmt.Sprintf("search:%s|%d|%s|%s|%s|%d", strings.ToLower(opts.Keyword), opts.Since.Unix(), normalizeSource(opts.Source), opts.Language, opts.Sort, opts.Limit)
+	news, hit, err := r.get(key, func() ([]News, error) {
+		return r.next.SearchNews(opts)
+	})
+	return publishedSince(news, since), hit, err
+}
+
+// get melayani key dari memori, lalu store persisten, lalu upstream. Pemanggil
+// yang ikut menunggu fetch yang sedang berjalan juga dihitung cache hit karena
+// tidak memicu request upstream sendiri.
+func (r *CachedRepository) get(key string, fetch func() ([]News, error)) ([]News, bool, error) {
+	if news, ok := r.lookup(key); ok {
+		return news, true, nil
+	}
+
+	// Fungsi di Do hanya dijalankan oleh satu pemanggil; yang lain menunggu hasilnya
+	fetched := false
+	value, err, _ := r.group.Do(key, func() (interface{}, error) {
+		// Fetch lain untuk key yang sama mungkin baru selesai
+		if news, ok := r.lookup(key); ok {
+			return news, nil
+		}
+
+		fetched = true
+		news, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		r.save(key, NewsCacheEntry{News: news, ExpiresAt: time.Now().Add(r.ttl)})
+		return news, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Salinan slice agar pemanggil bebas memfilter tanpa mengubah isi cache
+	news := value.([]News)
+	return append([]News(nil), news...), !fetched, nil
+}
+
+func (r *CachedRepository) lookup(key string) ([]News, bool) {
+	r.mu.Lock()
+	if element, ok := r.entries[key]; ok {
+		item := element.Value.(*cacheItem)
+		if time.Now().Before(item.entry.ExpiresAt) {
+			r.lru.MoveToFront(element)
+			r.mu.Unlock()
+			return append([]News(nil), item.entry.News...), true
+		}
+		r.lru.Remove(element)
+		delete(r.entries, key)
+	}
+	r.mu.Unlock()
+
+	if r.store == nil {
+		return nil, false
+	}
+
+	entry, ok, err := r.store.Get(key)
+	if err != nil {
+		log.Printf("⚠️ [CACHE] Failed to read persistent cache %s: %v", key, err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	r.remember(key, entry)
+	return append([]News(nil), entry.News...), true
+}
+
+func (r *CachedRepository) save(key string, entry NewsCacheEntry) {
+	r.remember(key, entry)
+
+	if r.store != nil {
+		if err := r.store.Put(key, entry); err != nil {
+			log.Printf("⚠️ [CACHE] Failed to write persistent cache %s: %v", key, err)
+		}
+	}
+}
+
+// remember menyimpan entry di LRU memori dan membuang entry paling lama jika penuh
+func (r *CachedRepository) remember(key string, entry NewsCacheEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if element, ok := r.entries[key]; ok {
+		element.Value.(*cacheItem).entry = entry
+		r.lru.MoveToFront(element)
+		return
+	}
+
+	r.entries[key] = r.lru.PushFront(&cacheItem{key: key, entry: entry})
+	for r.lru.Len() > r.capacity {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheItem).key)
+	}
+}
+
+// publishedSince membuang artikel yang terbit sebelum since; since nol berarti semua
+func publishedSince(news []News, since time.Time) []News {
+	filtered := news[:0]
+	for _, article := range news {
+		if !article.PublishedAt.Before(since) {
+			filtered = append(filtered, article)
+		}
+	}
+	return filtered
+}
+
+// BoltNewsCacheStore menyimpan cache berita per key di bucket "news_cache".
+// Entry kedaluwarsa dihapus saat store dibuka dan secara berkala lewat
+// StartPruning, bukan pada setiap Put, agar write tidak memindai seluruh bucket.
+type BoltNewsCacheStore struct {
+	db *bolt.DB
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewBoltNewsCacheStore(db *bolt.DB) (*BoltNewsCacheStore, error) {
+	store := &BoltNewsCacheStore{db: db}
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(newsCacheBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Prune(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *BoltNewsCacheStore) Get(key string) (NewsCacheEntry, bool, error) {
+	var entry NewsCacheEntry
+	found := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		found, err = getJSON(tx.Bucket(newsCacheBucket), key, &entry)
+		return err
+	})
+	if err != nil || !found {
+		return entry, false, err
+	}
+
+	return entry, time.Now().Before(entry.ExpiresAt), nil
+}
+
+func (s *BoltNewsCacheStore) Put(key string, entry NewsCacheEntry) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(newsCacheBucket), key, entry)
+	})
+}
+
+// Prune menghapus semua entry yang sudah kedaluwarsa
+func (s *BoltNewsCacheStore) Prune() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return pruneExpiredCache(tx.Bucket(newsCacheBucket), time.Now())
+	})
+}
+
+// StartPruning menjalankan Prune setiap interval di background sampai StopPruning
+// dipanggil. Pemanggilan berikutnya tidak berpengaruh selama pruning masih berjalan.
+func (s *BoltNewsCacheStore) StartPruning(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.prune(interval, s.stop, s.done)
+}
+
+func (s *BoltNewsCacheStore) prune(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := s.Prune(); err != nil {
+				log.Printf("⚠️ [CACHE] Failed to prune persistent cache: %v", err)
+			}
+		}
+	}
+}
+
+// StopPruning menghentikan pruning background dan menunggu goroutine-nya selesai
+func (s *BoltNewsCacheStore) StopPruning() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// pruneExpiredCache menghapus entry yang kedaluwarsa atau tidak bisa dibaca
+func pruneExpiredCache(bucket *bolt.Bucket, now time.Time) error {
+	var expired [][]byte
+	err := bucket.ForEach(func(key, value []byte) error {
+		var entry NewsCacheEntry
+		if err := json.Unmarshal(value, &entry); err != nil || !now.Before(entry.ExpiresAt) {
+			expired = append(expired, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
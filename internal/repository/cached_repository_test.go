@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// countingSource menghitung pemanggilan upstream; release (jika diisi) menahan
+// SearchNews sampai ditutup
+type countingSource struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+	err     error
+}
+
+func (s *countingSource) GetLatestNews() ([]News, error) {
+	return s.GetLatestNewsSince(time.Time{})
+}
+
+func (s *countingSource) GetLatestNewsSince(time.Time) ([]News, error) {
+	s.calls.Add(1)
+	return []News{{Title: "latest", URL: "https://a.com/1", PublishedAt: time.Now()}}, s.err
+}
+
+func (s *countingSource) SearchNews(opts SearchOptions) ([]News, error) {
+	if s.calls.Add(1) == 1 && s.started != nil {
+		close(s.started)
+	}
+	if s.release != nil {
+		<-s.release
+	}
+	return []News{{Title: opts.Keyword, URL: "https://a.com/" + opts.Keyword, PublishedAt: time.Now()}}, s.err
+}
+
+func TestCachedRepositoryExpiresAfterTTL(t *testing.T) {
+	upstream := &countingSource{}
+	repo := NewCachedRepository(upstream, time.Minute, 10, nil)
+
+	if _, hit, _ := repo.SearchNewsCached(SearchOptions{Keyword: "ai"}); hit {
+		t.Fatal("first search should miss")
+	}
+	if _, hit, _ := repo.SearchNewsCached(SearchOptions{Keyword: "ai"}); !hit {
+		t.Fatal("second search within the TTL should hit")
+	}
+
+	// Kedaluwarsakan entry tanpa menunggu TTL
+	repo.mu.Lock()
+	for _, element := range repo.entries {
+		element.Value.(*cacheItem).entry.ExpiresAt = time.Now().Add(-time.Second)
+	}
+	repo.mu.Unlock()
+
+	if _, hit, _ := repo.SearchNewsCached(SearchOptions{Keyword: "ai"}); hit {
+		t.Error("search after the TTL should miss")
+	}
+	if calls := upstream.calls.Load(); calls != 2 {
+		t.Errorf("upstream calls = %d, want 2", calls)
+	}
+}
+
+func TestCachedRepositoryDoesNotCacheErrors(t *testing.T) {
+	upstream := &countingSource{err: errors.New("upstream down")}
+	repo := NewCachedRepository(upstream, time.Minute, 10, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := repo.GetLatestNewsSinceCached(time.Now().Add(-time.Hour)); err == nil {
+			t.Fatal("error was not returned")
+		}
+	}
+	if calls := upstream.calls.Load(); calls != 2 {
+		t.Errorf("upstream calls = %d, want 2", calls)
+	}
+}
+
+func TestCachedRepositoryEvictsLeastRecentlyUsed(t *testing.T) {
+	upstream := &countingSource{}
+	repo := NewCachedRepository(upstream, time.Minute, 2, nil)
+
+	search := func(keyword string) bool {
+		t.Helper()
+		_, hit, err := repo.SearchNewsCached(SearchOptions{Keyword: keyword})
+		if err != nil {
+			t.Fatalf("SearchNewsCached(%q): %v", keyword, err)
+		}
+		return hit
+	}
+
+	search("a")
+	search("b")
+	search("a") // "b" sekarang paling lama tidak dipakai
+	search("c") // kapasitas 2: "b" dibuang
+
+	if !search("a") {
+		t.Error("recently used entry a was evicted")
+	}
+	if !search("c") {
+		t.Error("newest entry c was evicted")
+	}
+	if search("b") {
+		t.Error("least recently used entry b should have been evicted")
+	}
+}
+
+func TestCachedRepositoryCoalescesConcurrentRequests(t *testing.T) {
+	upstream := &countingSource{started: make(chan struct{}), release: make(chan struct{})}
+	repo := NewCachedRepository(upstream, time.Minute, 10, nil)
+
+	const callers = 10
+	var (
+		wg   sync.WaitGroup
+		hits atomic.Int32
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			news, hit, err := repo.SearchNewsCached(SearchOptions{Keyword: "rust"})
+			if err != nil || len(news) != 1 {
+				t.Errorf("SearchNewsCached = %v, %v", news, err)
+			}
+			if hit {
+				hits.Add(1)
+			}
+		}()
+	}
+
+	<-upstream.started
+	time.Sleep(20 * time.Millisecond) // beri waktu pemanggil lain ikut menunggu
+	close(upstream.release)
+	wg.Wait()
+
+	if calls := upstream.calls.Load(); calls != 1 {
+		t.Errorf("upstream calls = %d, want 1", calls)
+	}
+	if got := hits.Load(); got != callers-1 {
+		t.Errorf("cache hits = %d, want %d", got, callers-1)
+	}
+}
+
+func TestCachedRepositoryServesPersistentStore(t *testing.T) {
+	db := openTestDB(t)
+	store, err := NewBoltNewsCacheStore(db)
+	if err != nil {
+		t.Fatalf("NewBoltNewsCacheStore: %v", err)
+	}
+
+	upstream := &countingSource{}
+	if _, _, err := NewCachedRepository(upstream, time.Minute, 10, store).SearchNewsCached(SearchOptions{Keyword: "go"}); err != nil {
+		t.Fatalf("SearchNewsCached: %v", err)
+	}
+
+	// Repository baru (misal setelah restart) membaca entry dari store
+	news, hit, err := NewCachedRepository(upstream, time.Minute, 10, store).SearchNewsCached(SearchOptions{Keyword: "go"})
+	if err != nil || !hit || len(news) != 1 {
+		t.Errorf("after restart = %v, hit %t, %v; want one cached article", news, hit, err)
+	}
+	if calls := upstream.calls.Load(); calls != 1 {
+		t.Errorf("upstream calls = %d, want 1", calls)
+	}
+}
+
+func TestBoltNewsCacheStorePrunesOnTimer(t *testing.T) {
+	db := openTestDB(t)
+	store, err := NewBoltNewsCacheStore(db)
+	if err != nil {
+		t.Fatalf("NewBoltNewsCacheStore: %v", err)
+	}
+
+	expired := NewsCacheEntry{ExpiresAt: time.Now().Add(-time.Minute)}
+	fresh := NewsCacheEntry{ExpiresAt: time.Now().Add(time.Hour)}
+	for key, entry := range map[string]NewsCacheEntry{"old": expired, "new": fresh} {
+		if err := store.Put(key, entry); err != nil {
+			t.Fatalf("Put(%s): %v", key, err)
+		}
+	}
+
+	// Put tidak memangkas bucket; entry kedaluwarsa hanya tidak dilayani
+	if count := cacheKeys(t, db); count != 2 {
+		t.Fatalf("entries after Put = %d, want 2", count)
+	}
+	if _, ok, _ := store.Get("old"); ok {
+		t.Error("expired entry was served")
+	}
+
+	store.StartPruning(10 * time.Millisecond)
+	defer store.StopPruning()
+
+	deadline := time.Now().Add(time.Second)
+	for cacheKeys(t, db) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("expired entry was not pruned in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, ok, _ := store.Get("new"); !ok {
+		t.Error("fresh entry was pruned")
+	}
+}
+
+func cacheKeys(t *testing.T, db *bolt.DB) int {
+	t.Helper()
+	count := 0
+	err := db.View(func(tx *bolt.Tx) error {
+		count = tx.Bucket(newsCacheBucket).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("count cache entries: %v", err)
+	}
+	return count
+}
+
+func TestBoltNewsCacheStoreStopWaitsForRunningPrune(t *testing.T) {
+	db := openTestDB(t)
+	store, err := NewBoltNewsCacheStore(db)
+	if err != nil {
+		t.Fatalf("NewBoltNewsCacheStore: %v", err)
+	}
+	if err := store.Put("old", NewsCacheEntry{ExpiresAt: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	// Transaksi tulis yang terbuka menahan Prune di tengah jalan
+	tx, err := db.Begin(true)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	store.StartPruning(10 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	stopped := make(chan struct{})
+	go func() {
+		store.StopPruning()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		tx.Rollback()
+		t.Fatal("StopPruning returned while a prune was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	tx.Rollback()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("StopPruning did not return after the prune finished")
+	}
+
+	// Prune yang sedang berjalan diselesaikan, jadi database aman ditutup setelahnya
+	if count := cacheKeys(t, db); count != 0 {
+		t.Errorf("entries after StopPruning = %d, want the running prune to finish", count)
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Jenis error dari sumber berita. Gunakan errors.Is untuk membedakannya,
+// misalnya errors.Is(err, repository.ErrRateLimited).
+var (
+	ErrRateLimited  = errors.New("news source rate limited")
+	ErrAuthFailed   = errors.New("news source authentication failed")
+	ErrUpstreamDown = errors.New("news source unavailable")
+	ErrDecodeFailed = errors.New("failed to decode news source response")
+)
+
+// SourceError membungkus kegagalan satu sumber berita beserta jenisnya
+type SourceError struct {
+	Source     string
+	Kind       error
+	StatusCode int
+	Err        error
+}
+
+func (e *SourceError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Source, e.Kind)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (HTTP %d)", e.StatusCode)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *SourceError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+func newSourceError(source string, kind error, statusCode int, err error) *SourceError {
+	return &SourceError{
+		Source:     source,
+		Kind:       kind,
+		StatusCode: statusCode,
+		Err:        err,
+	}
+}
+
+// statusError memetakan HTTP status non-2xx ke jenis error yang sesuai
+func statusError(source string, statusCode int) *SourceError {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return newSourceError(source, ErrRateLimited, statusCode, nil)
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return newSourceError(source, ErrAuthFailed, statusCode, nil)
+	default:
+		return newSourceError(source, ErrUpstreamDown, statusCode, nil)
+	}
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestStatusErrorMapping(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrAuthFailed},
+		{http.StatusForbidden, ErrAuthFailed},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrUpstreamDown},
+		{http.StatusBadGateway, ErrUpstreamDown},
+		{http.StatusServiceUnavailable, ErrUpstreamDown},
+		{http.StatusNotFound, ErrUpstreamDown},
+	}
+	for _, tt := range tests {
+		err := statusError("newsapi", tt.status)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("HTTP %d: kind = %v, want %v", tt.status, err.Kind, tt.want)
+		}
+		if err.StatusCode != tt.status || err.Source != "newsapi" {
+			t.Errorf("HTTP %d: error = %+v", tt.status, err)
+		}
+	}
+}
+
+func TestSourceErrorUnwrap(t *testing.T) {
+	cause := io.ErrUnexpectedEOF
+	wrapped := fmt.Errorf("failed to fetch news: %w", newSourceError("rss", ErrDecodeFailed, 200, cause))
+
+	if !errors.Is(wrapped, ErrDecodeFailed) {
+		t.Error("errors.Is should find the kind")
+	}
+	if !errors.Is(wrapped, cause) {
+		t.Error("errors.Is should find the cause")
+	}
+	if errors.Is(wrapped, ErrRateLimited) {
+		t.Error("errors.Is matched an unrelated kind")
+	}
+
+	var sourceErr *SourceError
+	if !errors.As(wrapped, &sourceErr) || sourceErr.Source != "rss" || sourceErr.StatusCode != 200 {
+		t.Errorf("errors.As = %+v, want the rss SourceError", sourceErr)
+	}
+
+	if got, want := sourceErr.Error(), "rss: failed to decode news source response (HTTP 200): unexpected EOF"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := statusError("hackernews", 429).Error(), "hackernews: news source rate limited (HTTP 429)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestNewsAPIErrorCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusTooManyRequests, `{"status":"error","code":"rateLimited","message":"slow down"}`, ErrRateLimited},
+		{http.StatusUnauthorized, `{"status":"error","code":"apiKeyInvalid","message":"bad key"}`, ErrAuthFailed},
+		{http.StatusInternalServerError, `{"status":"error","code":"unexpectedError","message":"oops"}`, ErrUpstreamDown},
+		{http.StatusBadGateway, `<html>bad gateway</html>`, ErrUpstreamDown},
+		{http.StatusOK, `not json`, ErrDecodeFailed},
+	}
+	for _, tt := range tests {
+		repo := newNewsAPIServer(t, tt.status, tt.body)
+		_, err := repo.SearchNews(SearchOptions{Keyword: "ai"})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("HTTP %d %s: err = %v, want %v", tt.status, tt.body, err, tt.want)
+		}
+	}
+}
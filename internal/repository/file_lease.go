@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNotLeader dikembalikan FileLease jika lease sedang dipegang instance lain
+var ErrNotLeader = errors.New("not the leader")
+
+const lockRetry = 50 * time.Millisecond
+
+// FileLease adalah leader election berbasis file lease (implementasi gocron.Elector).
+// Semua replica menunjuk ke path yang sama (misal volume bersama); instance yang
+// memegang lease yang belum kedaluwarsa adalah leader. Lease diperpanjang oleh
+// StartRenewal di background (dan setiap kali leader menjalankan job), lalu diambil
+// alih instance lain jika tidak diperpanjang sampai ttl lewat.
+//
+// Akses ke file lease diserialisasi dengan lock OS pada file <path>.lock (flock di
+// Unix, LockFileEx di Windows). Lock dilepas kernel saat proses mati, jadi tidak ada
+// lock basi yang perlu dibersihkan dan file .lock tidak pernah dihapus.
+type FileLease struct {
+	path       string
+	instanceID string
+	ttl        time.Duration
+
+	mu     sync.Mutex
+	leader bool          // hasil IsLeader terakhir, untuk log pergantian leader
+	stop   chan struct{} // nil jika renewal background tidak berjalan
+	done   chan struct{}
+}
+
+type leaseRecord struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func NewFileLease(path, instanceID string, ttl time.Duration) (*FileLease, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create lease directory: %w", err)
+	}
+
+	return &FileLease{
+		path:       path,
+		instanceID: instanceID,
+		ttl:        ttl,
+	}, nil
+}
+
+// IsLeader mengambil atau memperpanjang lease. Mengembalikan nil jika instance ini
+// leader, atau error (ErrNotLeader) jika lease dipegang instance lain.
+func (l *FileLease) IsLeader(ctx context.Context) error {
+	err := l.campaign(ctx)
+	l.setLeader(err == nil)
+	return err
+}
+
+func (l *FileLease) campaign(ctx context.Context) error {
+	unlock, err := l.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	record, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if record.Owner != "" && record.Owner != l.instanceID && now.Before(record.ExpiresAt) {
+		return fmt.Errorf("%w: lease held by %s until %s", ErrNotLeader, record.Owner, record.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return l.write(leaseRecord{Owner: l.instanceID, ExpiresAt: now.Add(l.ttl)})
+}
+
+// setLeader mencatat status leader dan menulis log hanya saat status berubah
+func (l *FileLease) setLeader(leader bool) {
+	l.mu.Lock()
+	changed := l.leader != leader
+	l.leader = leader
+	l.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if leader {
+		log.Printf("👑 Instance %s acquired the leader lease", l.instanceID)
+	} else {
+		log.Printf("🔓 Instance %s is no longer the leader", l.instanceID)
+	}
+}
+
+// StartRenewal memperpanjang (atau mencoba mengambil) lease setiap sepertiga ttl di
+// background. Tanpa ini lease hanya diperpanjang saat job berjalan, sehingga leader
+// kehilangan lease di antara job yang jaraknya lebih lama dari ttl.
+func (l *FileLease) StartRenewal() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		return
+	}
+
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	go l.renew(l.stop, l.done)
+}
+
+func (l *FileLease) renew(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+		if err := l.IsLeader(ctx); err != nil && !errors.Is(err, ErrNotLeader) {
+			log.Printf("⚠️ Failed to renew leader lease: %v", err)
+		}
+		cancel()
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopRenewal menghentikan renewal background dan menunggu goroutine-nya selesai
+func (l *FileLease) stopRenewal() {
+	l.mu.Lock()
+	stop, done := l.stop, l.done
+	l.stop, l.done = nil, nil
+	l.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Release melepas lease saat shutdown agar replica lain bisa langsung mengambil alih
+func (l *FileLease) Release() error {
+	l.stopRenewal()
+	l.setLeader(false)
+
+	unlock, err := l.lock(context.Background())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	record, err := l.read()
+	if err != nil || record.Owner != l.instanceID {
+		return err
+	}
+	return os.Remove(l.path)
+}
+
+// lock mengambil lock eksklusif pada file .lock; dicoba ulang sampai ctx selesai
+func (l *FileLease) lock(ctx context.Context) (func(), error) {
+	file, err := os.OpenFile(l.path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lease lock: %w", err)
+	}
+
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock lease: %w", err)
+		}
+		if locked {
+			return func() {
+				unlockFile(file)
+				file.Close()
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			file.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockRetry):
+		}
+	}
+}
+
+func (l *FileLease) read() (leaseRecord, error) {
+	var record leaseRecord
+
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return record, nil
+	}
+	if err != nil {
+		return record, err
+	}
+
+	// Lease yang rusak diperlakukan seperti tidak ada
+	if err := json.Unmarshal(data, &record); err != nil {
+		return leaseRecord{}, nil
+	}
+	return record, nil
+}
+
+// write menulis lease ke file sementara lalu rename agar pembaca tidak melihat file setengah jadi
+func (l *FileLease) write(record leaseRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := l.path + "." + l.instanceID + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, l.path)
+}
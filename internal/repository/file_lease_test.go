@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestLeases(t *testing.T, ttl time.Duration) (*FileLease, *FileLease) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "leader.json")
+
+	first, err := NewFileLease(path, "replica-a", ttl)
+	if err != nil {
+		t.Fatalf("NewFileLease: %v", err)
+	}
+	second, err := NewFileLease(path, "replica-b", ttl)
+	if err != nil {
+		t.Fatalf("NewFileLease: %v", err)
+	}
+	return first, second
+}
+
+func TestFileLeaseElectsOneLeader(t *testing.T) {
+	first, second := newTestLeases(t, time.Minute)
+	ctx := context.Background()
+
+	if err := first.IsLeader(ctx); err != nil {
+		t.Fatalf("first replica should acquire a free lease: %v", err)
+	}
+	if err := second.IsLeader(ctx); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("second replica IsLeader = %v, want ErrNotLeader", err)
+	}
+	if err := first.IsLeader(ctx); err != nil {
+		t.Fatalf("leader should keep its own lease: %v", err)
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if err := second.IsLeader(ctx); err != nil {
+		t.Fatalf("second replica should take over a released lease: %v", err)
+	}
+
+	// Release oleh instance yang bukan pemilik tidak menghapus lease
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release by non-owner: %v", err)
+	}
+	if err := first.IsLeader(ctx); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("lease should still belong to the second replica, got %v", err)
+	}
+}
+
+func TestFileLeaseExpires(t *testing.T) {
+	first, second := newTestLeases(t, 100*time.Millisecond)
+	ctx := context.Background()
+
+	if err := first.IsLeader(ctx); err != nil {
+		t.Fatalf("IsLeader: %v", err)
+	}
+	if err := second.IsLeader(ctx); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("second replica IsLeader = %v, want ErrNotLeader", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if err := second.IsLeader(ctx); err != nil {
+		t.Fatalf("second replica should take over an expired lease: %v", err)
+	}
+}
+
+func TestFileLeaseRenewsInBackground(t *testing.T) {
+	first, second := newTestLeases(t, 150*time.Millisecond)
+	ctx := context.Background()
+
+	if err := first.IsLeader(ctx); err != nil {
+		t.Fatalf("IsLeader: %v", err)
+	}
+	first.StartRenewal()
+	t.Cleanup(func() { first.Release() })
+
+	// Tanpa job yang berjalan, lease tetap dipegang melewati beberapa kali ttl
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if err := second.IsLeader(ctx); !errors.Is(err, ErrNotLeader) {
+			t.Fatalf("renewed lease was taken over: %v", err)
+		}
+		time.Sleep(40 * time.Millisecond)
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if err := second.IsLeader(ctx); err != nil {
+		t.Fatalf("second replica should take over after release: %v", err)
+	}
+
+	// Renewal sudah berhenti, jadi lease tidak direbut kembali
+	time.Sleep(100 * time.Millisecond)
+	if err := second.IsLeader(ctx); err != nil {
+		t.Fatalf("stopped renewal took the lease back: %v", err)
+	}
+}
+
+func TestFileLeaseLockRespectsContext(t *testing.T) {
+	first, _ := newTestLeases(t, time.Minute)
+
+	unlock, err := first.lock(context.Background())
+	if err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := first.IsLeader(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("IsLeader while locked = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestFileLeaseContendingReplicas(t *testing.T) {
+	first, second := newTestLeases(t, time.Minute)
+	ctx := context.Background()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		leaders = map[string]int{}
+	)
+	for i := 0; i < 20; i++ {
+		for _, lease := range []*FileLease{first, second} {
+			wg.Add(1)
+			go func(lease *FileLease) {
+				defer wg.Done()
+				err := lease.IsLeader(ctx)
+				if err != nil && !errors.Is(err, ErrNotLeader) {
+					t.Errorf("IsLeader(%s): %v", lease.instanceID, err)
+					return
+				}
+				if err == nil {
+					mu.Lock()
+					leaders[lease.instanceID]++
+					mu.Unlock()
+				}
+			}(lease)
+		}
+	}
+	wg.Wait()
+
+	if len(leaders) != 1 {
+		t.Fatalf("leaders = %v, want exactly one replica to win the lease", leaders)
+	}
+}
+
+func TestFileLeaseIgnoresLeftoverLockFile(t *testing.T) {
+	first, _ := newTestLeases(t, time.Minute)
+
+	// File .lock sisa proses yang crash tidak lagi memegang lock OS
+	if err := os.WriteFile(first.path+".lock", nil, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := first.IsLeader(ctx); err != nil {
+		t.Fatalf("IsLeader with leftover lock file: %v", err)
+	}
+}
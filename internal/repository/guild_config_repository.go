@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"slices"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var guildConfigBucket = []byte("guild_configs")
+
+// GuildConfig adalah konfigurasi bot per guild (server Discord)
+type GuildConfig struct {
+	GuildID           string    `json:"guildId"`
+	NewsChannelIDs    []string  `json:"newsChannelIds,omitempty"`
+	CommandChannelIDs []string  `json:"commandChannelIds,omitempty"`
+	EnabledSchedules  []string  `json:"enabledSchedules,omitempty"`
+	Timezone          string    `json:"timezone,omitempty"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// ScheduleEnabled bernilai true jika jadwal aktif untuk guild ini.
+// EnabledSchedules yang kosong berarti semua jadwal aktif.
+func (c *GuildConfig) ScheduleEnabled(name string) bool {
+	if len(c.EnabledSchedules) == 0 {
+		return true
+	}
+	return slices.Contains(c.EnabledSchedules, name)
+}
+
+type GuildConfigRepository interface {
+	GetGuildConfig(guildID string) (*GuildConfig, error)
+	SaveGuildConfig(config *GuildConfig) error
+}
+
+type BoltGuildConfigRepository struct {
+	db *bolt.DB
+}
+
+func NewBoltGuildConfigRepository(db *bolt.DB) (*BoltGuildConfigRepository, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(guildConfigBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoltGuildConfigRepository{db: db}, nil
+}
+
+// GetGuildConfig mengembalikan konfigurasi kosong (bukan nil) jika guild belum dikonfigurasi
+func (r *BoltGuildConfigRepository) GetGuildConfig(guildID string) (*GuildConfig, error) {
+	config := &GuildConfig{GuildID: guildID}
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		_, err := getJSON(tx.Bucket(guildConfigBucket), guildID, config)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (r *BoltGuildConfigRepository) SaveGuildConfig(config *GuildConfig) error {
+	config.UpdatedAt = time.Now()
+
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(guildConfigBucket), config.GuildID, config)
+	})
+}
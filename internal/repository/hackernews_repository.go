@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// HNSearchResponse is the Algolia Hacker News search response
+type HNSearchResponse struct {
+	Hits []HNHit `json:"hits"`
+}
+
+type HNHit struct {
+	ObjectID    string `json:"objectID"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Author      string `json:"author"`
+	Points      int    `json:"points"`
+	NumComments int    `json:"num_comments"`
+	CreatedAtI  int64  `json:"created_at_i"`
+	StoryText   string `json:"story_text"`
+}
+
+type HackerNewsRepository struct {
+	client  *http.Client
+	baseURL string
+	itemURL string
+}
+
+func NewHackerNewsRepository() *HackerNewsRepository {
+	return &HackerNewsRepository{
+		client:  &http.Client{Timeout: 15 * time.Second},
+		baseURL: "https://hn.algolia.com/api/v1",
+		itemURL: "https://news.ycombinator.com/item?id=",
+	}
+}
+
+func (r *HackerNewsRepository) GetLatestNews() ([]News, error) {
+	since := time.Now().Add(-24 * time.Hour)
+	return r.GetLatestNewsSince(since)
+}
+
+func (r *HackerNewsRepository) GetLatestNewsSince(since time.Time) ([]News, error) {
+	log.Printf("🌐 DEBUG: Fetching top stories from Hacker News")
+
+	params := url.Values{}
+	params.Set("tags", "story")
+	params.Set("numericFilters", fmt.Sprintf("created_at_i>%d", since.Unix()))
+	params.Set("hitsPerPage", "30")
+
+	return r.search("/search", params)
+}
+
+func (r *HackerNewsRepository) SearchNews(opts SearchOptions) ([]News, error) {
+	log.Printf("🔍 DEBUG: Searching Hacker News for keyword: %s", opts.Keyword)
+
+	params := url.Values{}
+	params.Set("query", opts.Keyword)
+	params.Set("tags", "story")
+	params.Set("hitsPerPage", strconv.Itoa(max(20, min(opts.Limit, 100))))
+	if !opts.Since.IsZero() {
+		params.Set("numericFilters", fmt.Sprintf("created_at_i>%d", opts.Since.Unix()))
+	}
+
+	// /search_by_date mengurutkan dari yang terbaru
+	if opts.Sort == SortNewest {
+		return r.search("/search_by_date", params)
+	}
+	return r.search("/search", params)
+}
+
+// search memanggil endpoint pencarian Algolia; /search mengurutkan hasil
+// berdasarkan relevansi lalu points
+func (r *HackerNewsRepository) search(endpoint string, params url.Values) ([]News, error) {
+	resp, err := r.client.Get(r.baseURL + endpoint + "?" + params.Encode())
+	if err != nil {
+		log.Printf("❌ ERROR: Hacker News request failed: %v", err)
+		return nil, newSourceError("hackernews", ErrUpstreamDown, 0, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("hackernews", resp.StatusCode)
+	}
+
+	var searchResponse HNSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		log.Printf("❌ ERROR: Failed to decode Hacker News response: %v", err)
+		return nil, newSourceError("hackernews", ErrDecodeFailed, resp.StatusCode, err)
+	}
+
+	var news []News
+	for _, hit := range searchResponse.Hits {
+		if hit.Title == "" {
+			continue
+		}
+
+		// Ask HN / Show HN tanpa link eksternal diarahkan ke halaman diskusi
+		link := hit.URL
+		if link == "" {
+			link = r.itemURL + hit.ObjectID
+		}
+
+		news = append(news, News{
+			Title:       hit.Title,
+			Description: cleanText(hit.StoryText),
+			URL:         link,
+			PublishedAt: time.Unix(hit.CreatedAtI, 0),
+			Source:      "Hacker News",
+			Score:       hit.Points,
+			Comments:    hit.NumComments,
+			Language:    "en",
+		})
+	}
+
+	log.Printf("✅ DEBUG: Returning %d stories from Hacker News", len(news))
+	return news, nil
+}
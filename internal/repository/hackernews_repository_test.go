@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newHNServer menyajikan body untuk semua endpoint dan mencatat request terakhir
+func newHNServer(t *testing.T, status int, body string) (*HackerNewsRepository, *url.URL) {
+	t.Helper()
+	var last url.URL
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = *r.URL
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return &HackerNewsRepository{
+		client:  server.Client(),
+		baseURL: server.URL,
+		itemURL: "https://news.ycombinator.com/item?id=",
+	}, &last
+}
+
+func TestHackerNewsParsesHits(t *testing.T) {
+	repo, _ := newHNServer(t, http.StatusOK, `{"hits":[
+		{"objectID":"1","title":"Rust 2.0","url":"https://rust-lang.org/2","points":120,"num_comments":40,"created_at_i":1741600800},
+		{"objectID":"2","title":"Ask HN: Favourite editor?","story_text":"<p>Vim &amp; Emacs</p>","points":5,"created_at_i":1741600000},
+		{"objectID":"3","title":"","url":"https://dead.example.com"},
+		{"objectID":"4"}
+	]}`)
+
+	news, err := repo.GetLatestNewsSince(time.Time{})
+	if err != nil {
+		t.Fatalf("GetLatestNewsSince: %v", err)
+	}
+
+	tests := []News{
+		{Title: "Rust 2.0", URL: "https://rust-lang.org/2", PublishedAt: time.Unix(1741600800, 0), Source: "Hacker News", Score: 120, Comments: 40, Language: "en"},
+		{Title: "Ask HN: Favourite editor?", Description: "Vim & Emacs", URL: "https://news.ycombinator.com/item?id=2", PublishedAt: time.Unix(1741600000, 0), Source: "Hacker News", Score: 5, Language: "en"},
+	}
+	if len(news) != len(tests) {
+		t.Fatalf("got %d stories, want %d (dead and untitled items skipped): %+v", len(news), len(tests), news)
+	}
+	for i, want := range tests {
+		got := news[i]
+		if got.Title != want.Title || got.Description != want.Description || got.URL != want.URL ||
+			!got.PublishedAt.Equal(want.PublishedAt) || got.Source != want.Source ||
+			got.Score != want.Score || got.Comments != want.Comments || got.Language != want.Language {
+			t.Errorf("news[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func TestHackerNewsSinceFilter(t *testing.T) {
+	since := time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		call        func(repo *HackerNewsRepository) error
+		wantPath    string
+		wantNumeric string
+	}{
+		{
+			name: "latest",
+			call: func(repo *HackerNewsRepository) error {
+				_, err := repo.GetLatestNewsSince(since)
+				return err
+			},
+			wantPath:    "/search",
+			wantNumeric: "created_at_i>1741478400",
+		},
+		{
+			name: "search without since",
+			call: func(repo *HackerNewsRepository) error {
+				_, err := repo.SearchNews(SearchOptions{Keyword: "go"})
+				return err
+			},
+			wantPath:    "/search",
+			wantNumeric: "",
+		},
+		{
+			name: "search newest since",
+			call: func(repo *HackerNewsRepository) error {
+				_, err := repo.SearchNews(SearchOptions{Keyword: "go", Since: since, Sort: SortNewest})
+				return err
+			},
+			wantPath:    "/search_by_date",
+			wantNumeric: "created_at_i>1741478400",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, last := newHNServer(t, http.StatusOK, `{"hits":[]}`)
+			if err := tt.call(repo); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if last.Path != tt.wantPath {
+				t.Errorf("path = %s, want %s", last.Path, tt.wantPath)
+			}
+			if got := last.Query().Get("numericFilters"); got != tt.wantNumeric {
+				t.Errorf("numericFilters = %q, want %q", got, tt.wantNumeric)
+			}
+		})
+	}
+}
+
+func TestHackerNewsErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusTooManyRequests, ``, ErrRateLimited},
+		{http.StatusBadGateway, ``, ErrUpstreamDown},
+		{http.StatusOK, `{"hits":`, ErrDecodeFailed},
+	}
+	for _, tt := range tests {
+		repo, _ := newHNServer(t, tt.status, tt.body)
+		if _, err := repo.GetLatestNewsSince(time.Time{}); !errors.Is(err, tt.want) {
+			t.Errorf("status %d: err = %v, want %v", tt.status, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	articlesBucket = []byte("articles")
+	postedBucket   = []byte("posted")
+	messagesBucket = []byte("post_messages")
+)
+
+// ArticleRecord adalah artikel yang pernah dilihat bot dari sumber berita,
+// beserta statistik yang dipakai untuk ranking digest
+type ArticleRecord struct {
+	News       News      `json:"news"`
+	FirstSeen  time.Time `json:"firstSeen"`
+	LastSeen   time.Time `json:"lastSeen"`
+	PostCount  int       `json:"postCount,omitempty"`
+	LastPosted time.Time `json:"lastPosted,omitempty"`
+	Reactions  int       `json:"reactions,omitempty"`
+}
+
+// MessageRecord memetakan pesan Discord yang dikirim bot ke artikel di dalamnya,
+// agar reaksi pada pesan bisa dihitung ke artikel tersebut. URLs berisi URL kanonik.
+type MessageRecord struct {
+	URLs     []string  `json:"urls"`
+	PostedAt time.Time `json:"postedAt"`
+}
+
+// PostRecord mencatat kapan sebuah artikel dikirim ke sebuah channel
+type PostRecord struct {
+	URL      string    `json:"url"`
+	PostedAt time.Time `json:"postedAt"`
+}
+
+type HistoryRepository interface {
+	RecordSeen(news []News) error
+	RecordPosted(channelID, messageID string, news []News, postedAt time.Time) error
+	FilterUnposted(channelID string, news []News, since time.Time) ([]News, error)
+	// RecordReaction menambah (delta > 0) atau mengurangi jumlah reaksi artikel ke-article
+	// (mulai dari 0) di pesan messageID. article negatif berarti reaksi tidak menunjuk
+	// artikel tertentu dan hanya dihitung jika pesan berisi satu artikel.
+	// Pesan yang bukan kiriman bot diabaikan.
+	RecordReaction(messageID string, article, delta int) error
+	// ArticlesSince mengembalikan artikel yang dilihat atau dikirim sejak waktu since
+	ArticlesSince(since time.Time) ([]ArticleRecord, error)
+	// Prune menghapus riwayat yang terakhir aktif sebelum waktu before
+	Prune(before time.Time) (int, error)
+}
+
+// BoltHistoryRepository menyimpan riwayat artikel di database bbolt lokal.
+// Artikel disimpan per URL kanonik (CanonicalizeURL) di bucket "articles", riwayat posting disimpan di
+// bucket "posted" dengan sub-bucket per channel ID, dan isi setiap pesan yang
+// dikirim disimpan per message ID di bucket "post_messages".
+type BoltHistoryRepository struct {
+	db *bolt.DB
+}
+
+func NewBoltHistoryRepository(db *bolt.DB) (*BoltHistoryRepository, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{articlesBucket, postedBucket, messagesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoltHistoryRepository{db: db}, nil
+}
+
+func (r *BoltHistoryRepository) RecordSeen(news []News) error {
+	now := time.Now()
+
+	return r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(articlesBucket)
+		for _, article := range news {
+			key := CanonicalizeURL(article.URL)
+			var record ArticleRecord
+			found, err := getJSON(bucket, key, &record)
+			if err != nil {
+				return err
+			}
+			if !found {
+				record.FirstSeen = now
+			}
+			record.News = article
+			record.LastSeen = now
+
+			if err := putJSON(bucket, key, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *BoltHistoryRepository) RecordPosted(channelID, messageID string, news []News, postedAt time.Time) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		channelBucket, err := tx.Bucket(postedBucket).CreateBucketIfNotExists([]byte(channelID))
+		if err != nil {
+			return err
+		}
+
+		articles := tx.Bucket(articlesBucket)
+		message := MessageRecord{PostedAt: postedAt}
+
+		for _, article := range news {
+			key := CanonicalizeURL(article.URL)
+			record := PostRecord{URL: article.URL, PostedAt: postedAt}
+			if err := putJSON(channelBucket, key, record); err != nil {
+				return err
+			}
+
+			var articleRecord ArticleRecord
+			found, err := getJSON(articles, key, &articleRecord)
+			if err != nil {
+				return err
+			}
+			if !found {
+				articleRecord = ArticleRecord{News: article, FirstSeen: postedAt, LastSeen: postedAt}
+			}
+			articleRecord.PostCount++
+			articleRecord.LastPosted = postedAt
+			if err := putJSON(articles, key, articleRecord); err != nil {
+				return err
+			}
+
+			message.URLs = append(message.URLs, key)
+		}
+
+		if messageID == "" {
+			return nil
+		}
+		return putJSON(tx.Bucket(messagesBucket), messageID, message)
+	})
+}
+
+func (r *BoltHistoryRepository) RecordReaction(messageID string, article, delta int) error {
+	// Cek dulu dengan transaksi baca agar reaksi pada pesan lain tidak memicu write
+	var message MessageRecord
+	var found bool
+	err := r.db.View(func(tx *bolt.Tx) error {
+		var err error
+		found, err = getJSON(tx.Bucket(messagesBucket), messageID, &message)
+		return err
+	})
+	if err != nil || !found {
+		return err
+	}
+
+	if article < 0 && len(message.URLs) == 1 {
+		article = 0
+	}
+	if article < 0 || article >= len(message.URLs) {
+		return nil
+	}
+	url := message.URLs[article]
+
+	return r.db.Update(func(tx *bolt.Tx) error {
+		articles := tx.Bucket(articlesBucket)
+
+		var record ArticleRecord
+		found, err := getJSON(articles, url, &record)
+		if err != nil || !found {
+			return err
+		}
+
+		record.Reactions = max(record.Reactions+delta, 0)
+		return putJSON(articles, url, record)
+	})
+}
+
+func (r *BoltHistoryRepository) ArticlesSince(since time.Time) ([]ArticleRecord, error) {
+	var records []ArticleRecord
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(articlesBucket).ForEach(func(key, value []byte) error {
+			var record ArticleRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+			if record.LastSeen.Before(since) && record.LastPosted.Before(since) {
+				return nil
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+
+	return records, err
+}
+
+func (r *BoltHistoryRepository) Prune(before time.Time) (int, error) {
+	var pruned int
+
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		articles := tx.Bucket(articlesBucket)
+		var staleArticles [][]byte
+		err := articles.ForEach(func(key, value []byte) error {
+			var record ArticleRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+			if record.LastSeen.Before(before) && record.LastPosted.Before(before) {
+				staleArticles = append(staleArticles, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range staleArticles {
+			if err := articles.Delete(key); err != nil {
+				return err
+			}
+		}
+		pruned = len(staleArticles)
+
+		messages := tx.Bucket(messagesBucket)
+		var staleMessages [][]byte
+		err = messages.ForEach(func(key, value []byte) error {
+			var record MessageRecord
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+			if record.PostedAt.Before(before) {
+				staleMessages = append(staleMessages, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range staleMessages {
+			if err := messages.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		return tx.Bucket(postedBucket).ForEachBucket(func(channelID []byte) error {
+			channelBucket := tx.Bucket(postedBucket).Bucket(channelID)
+			var stalePosts [][]byte
+			err := channelBucket.ForEach(func(key, value []byte) error {
+				var record PostRecord
+				if err := json.Unmarshal(value, &record); err != nil {
+					return err
+				}
+				if record.PostedAt.Before(before) {
+					stalePosts = append(stalePosts, append([]byte(nil), key...))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, key := range stalePosts {
+				if err := channelBucket.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+
+	return pruned, err
+}
+
+// FilterUnposted membuang artikel yang sudah dikirim ke channel sejak waktu since
+func (r *BoltHistoryRepository) FilterUnposted(channelID string, news []News, since time.Time) ([]News, error) {
+	var fresh []News
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		channelBucket := tx.Bucket(postedBucket).Bucket([]byte(channelID))
+		if channelBucket == nil {
+			fresh = news
+			return nil
+		}
+
+		for _, article := range news {
+			var record PostRecord
+			found, err := getJSON(channelBucket, CanonicalizeURL(article.URL), &record)
+			if err != nil {
+				return err
+			}
+			if found && record.PostedAt.After(since) {
+				continue
+			}
+			fresh = append(fresh, article)
+		}
+		return nil
+	})
+
+	return fresh, err
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryFilterUnpostedMatchesCanonicalURL(t *testing.T) {
+	repo, err := NewBoltHistoryRepository(openTestDB(t))
+	if err != nil {
+		t.Fatalf("NewBoltHistoryRepository: %v", err)
+	}
+
+	now := time.Now()
+	posted := News{Title: "Story", URL: "http://www.example.com/story?utm_source=rss"}
+	if err := repo.RecordPosted("chan-1", "msg-1", []News{posted}, now); err != nil {
+		t.Fatalf("RecordPosted: %v", err)
+	}
+
+	sameStory := News{Title: "Story", URL: "https://example.com/story/#top"}
+	other := News{Title: "Other", URL: "https://example.com/other"}
+	fresh, err := repo.FilterUnposted("chan-1", []News{sameStory, other}, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("FilterUnposted: %v", err)
+	}
+	if len(fresh) != 1 || fresh[0].URL != other.URL {
+		t.Fatalf("fresh = %+v, want only %s", fresh, other.URL)
+	}
+
+	fresh, err = repo.FilterUnposted("chan-2", []News{sameStory}, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("FilterUnposted other channel: %v", err)
+	}
+	if len(fresh) != 1 {
+		t.Errorf("posting to chan-1 should not filter chan-2, got %+v", fresh)
+	}
+
+	records, err := repo.ArticlesSince(now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ArticlesSince: %v", err)
+	}
+	if len(records) != 1 || records[0].News.URL != posted.URL {
+		t.Fatalf("records = %+v, want original URL kept", records)
+	}
+}
+
+func TestHistoryRecordReactionCreditsOneArticle(t *testing.T) {
+	repo, err := NewBoltHistoryRepository(openTestDB(t))
+	if err != nil {
+		t.Fatalf("NewBoltHistoryRepository: %v", err)
+	}
+
+	now := time.Now()
+	first := News{Title: "First", URL: "https://example.com/first"}
+	second := News{Title: "Second", URL: "https://example.com/second"}
+	single := News{Title: "Single", URL: "https://example.com/single"}
+	if err := repo.RecordPosted("chan-1", "msg-multi", []News{first, second}, now); err != nil {
+		t.Fatalf("RecordPosted: %v", err)
+	}
+	if err := repo.RecordPosted("chan-1", "msg-single", []News{single}, now); err != nil {
+		t.Fatalf("RecordPosted: %v", err)
+	}
+
+	steps := []struct {
+		messageID string
+		article   int
+		delta     int
+	}{
+		{"msg-multi", 1, 1},  // angka menunjuk artikel kedua
+		{"msg-multi", -1, 1}, // emoji umum di pesan multi artikel diabaikan
+		{"msg-multi", 7, 1},  // di luar jumlah artikel diabaikan
+		{"msg-single", -1, 1},
+		{"msg-single", -1, 1},
+		{"msg-single", -1, -1},
+		{"msg-unknown", 0, 1},
+	}
+	for _, step := range steps {
+		if err := repo.RecordReaction(step.messageID, step.article, step.delta); err != nil {
+			t.Fatalf("RecordReaction(%s, %d): %v", step.messageID, step.article, err)
+		}
+	}
+
+	records, err := repo.ArticlesSince(now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ArticlesSince: %v", err)
+	}
+	want := map[string]int{first.URL: 0, second.URL: 1, single.URL: 1}
+	for _, record := range records {
+		if got := record.Reactions; got != want[record.News.URL] {
+			t.Errorf("%s reactions = %d, want %d", record.News.URL, got, want[record.News.URL])
+		}
+	}
+}
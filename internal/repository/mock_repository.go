@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"strings"
+	"time"
+)
+
+// MockNewsRepository menyajikan berita contoh untuk demo/offline mode.
+// Hanya dipakai jika DEMO_MODE diaktifkan secara eksplisit.
+type MockNewsRepository struct{}
+
+func NewMockNewsRepository() *MockNewsRepository {
+	return &MockNewsRepository{}
+}
+
+func (r *MockNewsRepository) GetLatestNews() ([]News, error) {
+	return r.getMockNews(), nil
+}
+
+func (r *MockNewsRepository) GetLatestNewsSince(since time.Time) ([]News, error) {
+	return r.getMockNews(), nil
+}
+
+func (r *MockNewsRepository) SearchNews(opts SearchOptions) ([]News, error) {
+	keywordLower := strings.ToLower(opts.Keyword)
+
+	var news []News
+	for _, article := range r.getMockNews() {
+		content := strings.ToLower(article.Title + " " + article.Description)
+		if strings.Contains(content, keywordLower) && !article.PublishedAt.Before(opts.Since) {
+			news = append(news, article)
+		}
+	}
+	return news, nil
+}
+
+func (r *MockNewsRepository) getMockNews() []News {
+	return []News{
+		{
+			Title:       "🚀 AI Revolution: GPT-5 Released with Breakthrough Capabilities",
+			Description: "OpenAI announces GPT-5 with unprecedented reasoning abilities.",
+			URL:         "https://example.com/gpt5-release",
+			PublishedAt: time.Now().Add(-1 * time.Hour),
+			Source:      "Demo Data",
+			Score:       1547,
+			Comments:    423,
+		},
+		{
+			Title:       "💻 Quantum Computing Reaches New Milestone",
+			Description: "IBM's new quantum processor achieves 1000+ qubit stability.",
+			URL:         "https://example.com/quantum-breakthrough",
+			PublishedAt: time.Now().Add(-2 * time.Hour),
+			Source:      "Demo Data",
+			Score:       1205,
+			Comments:    287,
+		},
+		{
+			Title:       "🌐 Web 3.0 Adoption Accelerates in 2025",
+			Description: "Decentralized applications see 400% growth as mainstream adoption takes off.",
+			URL:         "https://example.com/web3-growth",
+			PublishedAt: time.Now().Add(-3 * time.Hour),
+			Source:      "Demo Data",
+			Score:       892,
+			Comments:    156,
+		},
+		{
+			Title:       "🔧 New Go Framework Simplifies Microservices Development",
+			Description: "Developer-friendly framework reduces boilerplate by 70%.",
+			URL:         "https://example.com/go-framework",
+			PublishedAt: time.Now().Add(-4 * time.Hour),
+			Source:      "Demo Data",
+			Score:       756,
+			Comments:    198,
+		},
+		{
+			Title:       "🛡️ Zero-Day Vulnerability Discovered in Popular JavaScript Library",
+			Description: "Security researchers urge immediate updates.",
+			URL:         "https://example.com/js-vulnerability",
+			PublishedAt: time.Now().Add(-5 * time.Hour),
+			Source:      "Demo Data",
+			Score:       2341,
+			Comments:    534,
+		},
+	}
+}
@@ -6,20 +6,49 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type News struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	URL         string    `json:"url"`
-	PublishedAt time.Time `json:"publishedAt"`
-	Source      string    `json:"source"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	URL            string    `json:"url"`
+	PublishedAt    time.Time `json:"publishedAt"`
+	Source         string    `json:"source"`
+	Score          int       `json:"score,omitempty"`
+	Comments       int       `json:"comments,omitempty"`
+	ImageURL       string    `json:"imageUrl,omitempty"`
+	RelatedSources []string  `json:"relatedSources,omitempty"`
+	// Language adalah kode bahasa ISO 639-1; kosong jika sumber tidak menyebutkannya
+	Language string `json:"language,omitempty"`
+}
+
+// Urutan hasil pencarian
+const (
+	SortRelevance  = "relevance"
+	SortPopularity = "popularity"
+	SortNewest     = "newest"
+)
+
+// SearchOptions adalah filter pencarian dari argumen command. Sumber yang tidak
+// mendukung suatu filter mengabaikannya; service menyaring ulang hasil gabungannya.
+type SearchOptions struct {
+	Keyword  string
+	Since    time.Time // nol: tanpa batas waktu
+	Source   string    // nama sumber, misal "wired" atau "hackernews"
+	Language string    // kode bahasa ISO 639-1, misal "en"
+	Limit    int       // 0: default tiap sumber
+	Sort     string    // SortRelevance (default), SortPopularity atau SortNewest
 }
 
 type NewsAPIResponse struct {
 	Status       string        `json:"status"`
+	Code         string        `json:"code,omitempty"`
+	Message      string        `json:"message,omitempty"`
 	TotalResults int           `json:"totalResults"`
 	Articles     []NewsArticle `json:"articles"`
 }
@@ -43,7 +72,7 @@ type NewsSource struct {
 type NewsRepository interface {
 	GetLatestNews() ([]News, error)
 	GetLatestNewsSince(since time.Time) ([]News, error)
-	SearchNews(keyword string) ([]News, error)
+	SearchNews(opts SearchOptions) ([]News, error)
 }
 
 type NewsApiRepository struct {
@@ -74,115 +103,95 @@ func (r *NewsApiRepository) GetLatestNewsSince(since time.Time) ([]News, error)
 	log.Printf("🌐 DEBUG: Fetching tech news from News API")
 
 	fromDate := since.Format("2006-01-02")
-	url := fmt.Sprintf("%s/everything?q=technology&from=%s&sortBy=popularity&pageSize=20&apiKey=%s",
-		r.baseURL, fromDate, r.apiKey)
-
-	log.Printf("🔗 DEBUG: NewsAPI URL (without API key): %s/everything?q=technology&from=%s&sortBy=popularity&pageSize=20&apiKey=***", r.baseURL, fromDate)
+	query := fmt.Sprintf("everything?q=technology&from=%s&sortBy=popularity&pageSize=20", fromDate)
 
-	resp, err := r.client.Get(url)
+	all, err := r.fetch(query)
 	if err != nil {
 		log.Printf("❌ ERROR: NewsAPI failed: %v", err)
-		return r.getMockNews(), nil
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	log.Printf("📊 DEBUG: Response Status: %s", resp.Status)
-	log.Printf("📊 DEBUG: Response Status Code: %d", resp.StatusCode)
 
-	// Read response body as bytes first for debugging
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("❌ ERROR: Failed to read response body: %v", err)
-		return r.getMockNews(), nil
+	// Parameter from NewsAPI hanya per tanggal; artikel tanpa tanggal valid juga dibuang
+	var news []News
+	for _, article := range all {
+		if !article.PublishedAt.Before(since) {
+			news = append(news, article)
+		}
 	}
 
-	log.Printf("📋 DEBUG: Raw response body length: %d bytes", len(bodyBytes))
-	if len(bodyBytes) == 0 {
-		log.Printf("❌ ERROR: Empty response body from NewsAPI")
-		return r.getMockNews(), nil
-	}
+	log.Printf("✅ DEBUG: Returning %d tech news articles from NewsAPI", len(news))
+	return news, nil
+}
 
-	// Log first 500 characters of response for debugging
-	if len(bodyBytes) > 500 {
-		log.Printf("📋 DEBUG: Response preview: %s...", string(bodyBytes[:500]))
-	} else {
-		log.Printf("📋 DEBUG: Full response: %s", string(bodyBytes))
-	}
+func (r *NewsApiRepository) SearchNews(opts SearchOptions) ([]News, error) {
+	log.Printf("🔍 DEBUG: Searching NewsAPI for keyword: %s", opts.Keyword)
 
-	var apiResponse NewsAPIResponse
-	if err := json.Unmarshal(bodyBytes, &apiResponse); err != nil {
-		log.Printf("❌ ERROR: Failed to decode NewsAPI response: %v", err)
-		log.Printf("📋 DEBUG: Response body was: %s", string(bodyBytes))
-		return r.getMockNews(), nil
+	// Frasa diberi tanda kutip agar NewsAPI mencari frasa utuh, bukan kata terpisah
+	query := opts.Keyword
+	if strings.Contains(query, " ") {
+		query = `"` + query + `"`
 	}
 
-	if apiResponse.Status != "ok" {
-		log.Printf("❌ ERROR: NewsAPI returned status %s", apiResponse.Status)
-		return r.getMockNews(), nil
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("sortBy", newsAPISort(opts.Sort))
+	params.Set("pageSize", strconv.Itoa(max(10, min(opts.Limit, 100))))
+	if !opts.Since.IsZero() {
+		params.Set("from", opts.Since.Format("2006-01-02"))
 	}
-
-	log.Printf("✅ SUCCESS: Got %d articles from NewsAPI", len(apiResponse.Articles))
-
-	var news []News
-	for _, article := range apiResponse.Articles {
-		publishedAt, err := time.Parse(time.RFC3339, article.PublishedAt)
-		if err != nil {
-			publishedAt = time.Now()
-		}
-
-		if article.Title != "" && article.URL != "" {
-			news = append(news, News{
-				Title:       article.Title,
-				Description: article.Description,
-				URL:         article.URL,
-				PublishedAt: publishedAt,
-				Source:      article.Source.Name,
-			})
-
-			log.Printf("📰 DEBUG: Added article: %s from %s",
-				article.Title, article.Source.Name)
-		}
+	if opts.Language != "" {
+		params.Set("language", opts.Language)
 	}
 
-	if len(news) == 0 {
-		log.Println("⚠️ WARNING: No valid articles found in NewsAPI response, using mock data")
-		return r.getMockNews(), nil
+	news, err := r.fetch("everything?" + params.Encode())
+	if err != nil {
+		log.Printf("❌ ERROR: NewsAPI search failed: %v", err)
+		return nil, err
 	}
 
-	log.Printf("✅ DEBUG: Returning %d tech news articles from NewsAPI", len(news))
+	log.Printf("✅ DEBUG: Returning %d search results from NewsAPI", len(news))
 	return news, nil
 }
 
-func (r *NewsApiRepository) SearchNews(keyword string) ([]News, error) {
-	log.Printf("🔍 DEBUG: Searching NewsAPI for keyword: %s", keyword)
-
-	url := fmt.Sprintf("%s/everything?q=%s&sortBy=relevancy&pageSize=10&apiKey=%s",
-		r.baseURL, keyword, r.apiKey)
+// fetch memanggil endpoint NewsAPI dan memetakan kegagalan ke SourceError
+func (r *NewsApiRepository) fetch(query string) ([]News, error) {
+	log.Printf("🔗 DEBUG: NewsAPI URL (without API key): %s/%s&apiKey=***", r.baseURL, query)
 
-	resp, err := r.client.Get(url)
+	resp, err := r.client.Get(fmt.Sprintf("%s/%s&apiKey=%s", r.baseURL, query, r.apiKey))
 	if err != nil {
-		log.Printf("❌ ERROR: NewsAPI search failed: %v", err)
-		return nil, err
+		return nil, newSourceError("newsapi", ErrUpstreamDown, 0, err)
 	}
-
 	defer resp.Body.Close()
 
+	log.Printf("📊 DEBUG: Response Status: %s", resp.Status)
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newSourceError("newsapi", ErrUpstreamDown, resp.StatusCode, err)
+	}
+
 	var apiResponse NewsAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		log.Printf("❌ ERROR: Failed to decode NewsAPI search response: %v", err)
-		return r.getMockNews(), nil
+	if err := json.Unmarshal(bodyBytes, &apiResponse); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, statusError("newsapi", resp.StatusCode)
+		}
+		return nil, newSourceError("newsapi", ErrDecodeFailed, resp.StatusCode, err)
 	}
 
 	if apiResponse.Status != "ok" {
-		log.Printf("❌ ERROR: NewsAPI search returned status %s", apiResponse.Status)
-		return r.getMockNews(), nil
+		return nil, newSourceError("newsapi", newsAPIErrorKind(apiResponse.Code, resp.StatusCode),
+			resp.StatusCode, fmt.Errorf("%s: %s", apiResponse.Code, apiResponse.Message))
 	}
 
+	log.Printf("✅ SUCCESS: Got %d articles from NewsAPI", len(apiResponse.Articles))
+
 	var news []News
 	for _, article := range apiResponse.Articles {
+		// Sama seperti feed RSS: tanggal yang tidak valid menjadi waktu nol agar
+		// artikel tidak terlihat selalu baru
 		publishedAt, err := time.Parse(time.RFC3339, article.PublishedAt)
 		if err != nil {
-			publishedAt = time.Now()
+			publishedAt = time.Time{}
 		}
 
 		if article.Title != "" && article.URL != "" {
@@ -192,58 +201,32 @@ func (r *NewsApiRepository) SearchNews(keyword string) ([]News, error) {
 				URL:         article.URL,
 				PublishedAt: publishedAt,
 				Source:      article.Source.Name,
+				ImageURL:    article.URLToImage,
 			})
-
-			log.Printf("📰 DEBUG: Added search result: %s from %s",
-				article.Title, article.Source.Name)
 		}
 	}
 
-	if len(news) == 0 {
-		log.Println("⚠️ WARNING: No valid articles found in NewsAPI response, using mock data")
-		return r.getMockNews(), nil
-	}
-
-	log.Printf("✅ DEBUG: Returning %d tech news articles from NewsAPI", len(news))
 	return news, nil
 }
 
-func (r *NewsApiRepository) getMockNews() []News {
-	return []News{
-		{
-			Title:       "🚀 AI Revolution: GPT-5 Released with Breakthrough Capabilities",
-			Description: "⭐ 1547 points • 💬 423 comments | OpenAI announces GPT-5 with unprecedented reasoning abilities.",
-			URL:         "https://example.com/gpt5-release",
-			PublishedAt: time.Now().Add(-1 * time.Hour),
-			Source:      "Mock News",
-		},
-		{
-			Title:       "💻 Quantum Computing Reaches New Milestone",
-			Description: "⭐ 1205 points • 💬 287 comments | IBM's new quantum processor achieves 1000+ qubit stability.",
-			URL:         "https://example.com/quantum-breakthrough",
-			PublishedAt: time.Now().Add(-2 * time.Hour),
-			Source:      "Mock News",
-		},
-		{
-			Title:       "🌐 Web 3.0 Adoption Accelerates in 2025",
-			Description: "⭐ 892 points • 💬 156 comments | Decentralized applications see 400% growth as mainstream adoption takes off.",
-			URL:         "https://example.com/web3-growth",
-			PublishedAt: time.Now().Add(-3 * time.Hour),
-			Source:      "Mock News",
-		},
-		{
-			Title:       "🔧 New Go Framework Simplifies Microservices Development",
-			Description: "⭐ 756 points • 💬 198 comments | Developer-friendly framework reduces boilerplate by 70%.",
-			URL:         "https://example.com/go-framework",
-			PublishedAt: time.Now().Add(-4 * time.Hour),
-			Source:      "Mock News",
-		},
-		{
-			Title:       "🛡️ Zero-Day Vulnerability Discovered in Popular JavaScript Library",
-			Description: "⭐ 2341 points • 💬 534 comments | Security researchers urge immediate updates.",
-			URL:         "https://example.com/js-vulnerability",
-			PublishedAt: time.Now().Add(-5 * time.Hour),
-			Source:      "Mock News",
-		},
+// newsAPISort memetakan SearchOptions.Sort ke parameter sortBy NewsAPI
+func newsAPISort(sort string) string {
+	switch sort {
+	case SortPopularity:
+		return "popularity"
+	case SortNewest:
+		return "publishedAt"
+	}
+	return "relevancy"
+}
+
+// newsAPIErrorKind memetakan kode error NewsAPI (https://newsapi.org/docs/errors)
+func newsAPIErrorKind(code string, statusCode int) error {
+	switch code {
+	case "rateLimited", "apiKeyExhausted":
+		return ErrRateLimited
+	case "apiKeyDisabled", "apiKeyInvalid", "apiKeyMissing":
+		return ErrAuthFailed
 	}
+	return statusError("newsapi", statusCode).Kind
 }
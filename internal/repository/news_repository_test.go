@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newNewsAPIServer menyajikan body sebagai respons NewsAPI
+func newNewsAPIServer(t *testing.T, status int, body string) *NewsApiRepository {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return &NewsApiRepository{client: server.Client(), baseURL: server.URL, apiKey: "test"}
+}
+
+func TestNewsAPIInvalidDatesAreZero(t *testing.T) {
+	repo := newNewsAPIServer(t, http.StatusOK, `{"status":"ok","articles":[
+		{"title":"Dated","url":"https://a.com/1","source":{"name":"A"},"publishedAt":"2025-03-10T10:00:00Z"},
+		{"title":"Garbled","url":"https://a.com/2","source":{"name":"A"},"publishedAt":"yesterday"},
+		{"title":"Missing","url":"https://a.com/3","source":{"name":"A"}}
+	]}`)
+
+	news, err := repo.SearchNews(SearchOptions{Keyword: "ai"})
+	if err != nil {
+		t.Fatalf("SearchNews: %v", err)
+	}
+	if len(news) != 3 {
+		t.Fatalf("got %d articles, want 3", len(news))
+	}
+	if !news[0].PublishedAt.Equal(time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("dated article = %s", news[0].PublishedAt)
+	}
+	for _, article := range news[1:] {
+		if !article.PublishedAt.IsZero() {
+			t.Errorf("%s: PublishedAt = %s, want zero", article.Title, article.PublishedAt)
+		}
+	}
+
+	latest, err := repo.GetLatestNewsSince(time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetLatestNewsSince: %v", err)
+	}
+	if len(latest) != 1 || latest[0].Title != "Dated" {
+		t.Errorf("latest = %+v, want only the dated article", latest)
+	}
+}
@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRSSFeeds dipakai ketika RSS_FEEDS tidak di-set
+var DefaultRSSFeeds = []string{
+	"https://techcrunch.com/feed/",
+	"https://www.theverge.com/rss/index.xml",
+	"https://feeds.arstechnica.com/arstechnica/index",
+	"https://www.wired.com/feed/rss",
+}
+
+// rssDocument covers RSS 2.0 (<rss>) and Atom 1.0 (<feed>) documents.
+// Only the fields we map into News are declared.
+type rssDocument struct {
+	XMLName xml.Name
+	Channel rssChannel  `xml:"channel"`
+	Title   string      `xml:"title"`
+	Lang    string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssChannel struct {
+	Title    string    `xml:"title"`
+	Language string    `xml:"language"`
+	Items    []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title        string         `xml:"title"`
+	Link         string         `xml:"link"`
+	Description  string         `xml:"description"`
+	PubDate      string         `xml:"pubDate"`
+	Date         string         `xml:"http://purl.org/dc/elements/1.1/ date"`
+	GUID         string         `xml:"guid"`
+	Enclosures   []rssEnclosure `xml:"enclosure"`
+	MediaContent []rssMedia     `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails   []rssMedia     `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type rssEnclosure struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssMedia struct {
+	URL    string `xml:"url,attr"`
+	Medium string `xml:"medium,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type atomEntry struct {
+	Title      string     `xml:"title"`
+	Links      []atomLink `xml:"link"`
+	Summary    string     `xml:"summary"`
+	Content    string     `xml:"content"`
+	Published  string     `xml:"published"`
+	Updated    string     `xml:"updated"`
+	ID         string     `xml:"id"`
+	Thumbnails []rssMedia `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type RSSRepository struct {
+	client *http.Client
+	feeds  []string
+}
+
+func NewRSSRepository(feeds []string) *RSSRepository {
+	if len(feeds) == 0 {
+		feeds = DefaultRSSFeeds
+	}
+
+	return &RSSRepository{
+		client: &http.Client{Timeout: 15 * time.Second},
+		feeds:  feeds,
+	}
+}
+
+func (r *RSSRepository) GetLatestNews() ([]News, error) {
+	since := time.Now().Add(-24 * time.Hour)
+	return r.GetLatestNewsSince(since)
+}
+
+func (r *RSSRepository) GetLatestNewsSince(since time.Time) ([]News, error) {
+	log.Printf("🌐 DEBUG: Fetching tech news from %d RSS/Atom feeds", len(r.feeds))
+
+	all, err := r.fetchAll(r.feeds)
+	if err != nil {
+		return nil, err
+	}
+
+	var news []News
+	for _, article := range all {
+		if article.PublishedAt.Before(since) {
+			continue
+		}
+		news = append(news, article)
+	}
+
+	sortNewestFirst(news)
+
+	log.Printf("✅ DEBUG: Returning %d tech news articles from RSS feeds", len(news))
+	return news, nil
+}
+
+func (r *RSSRepository) SearchNews(opts SearchOptions) ([]News, error) {
+	log.Printf("🔍 DEBUG: Searching RSS feeds for keyword: %s", opts.Keyword)
+
+	all, err := r.fetchAll(r.feedsFor(opts.Source))
+	if err != nil {
+		return nil, err
+	}
+
+	keywordLower := strings.ToLower(opts.Keyword)
+
+	var news []News
+	for _, article := range all {
+		content := strings.ToLower(article.Title + " " + article.Description)
+		// Artikel tanpa tanggal tetap bisa dicari selama tidak ada filter --since
+		if strings.Contains(content, keywordLower) && !article.PublishedAt.Before(opts.Since) {
+			news = append(news, article)
+		}
+	}
+
+	sortNewestFirst(news)
+
+	log.Printf("✅ DEBUG: Returning %d search results from RSS feeds", len(news))
+	return news, nil
+}
+
+// MatchesSource bernilai true jika salah satu feed berasal dari penerbit
+// tersebut, dicocokkan dengan host URL feed (misal "wired" → www.wired.com)
+func (r *RSSRepository) MatchesSource(source string) bool {
+	return len(r.matchingFeeds(source)) > 0
+}
+
+// feedsFor hanya mengambil feed dari penerbit yang diminta; tanpa --source
+// atau jika tidak ada feed yang cocok, semua feed diambil.
+func (r *RSSRepository) feedsFor(source string) []string {
+	if feeds := r.matchingFeeds(source); len(feeds) > 0 {
+		return feeds
+	}
+	return r.feeds
+}
+
+func (r *RSSRepository) matchingFeeds(source string) []string {
+	if source == "" {
+		return nil
+	}
+
+	var feeds []string
+	for _, feedURL := range r.feeds {
+		parsed, err := url.Parse(feedURL)
+		if err == nil && SourceMatches(parsed.Hostname(), source) {
+			feeds = append(feeds, feedURL)
+		}
+	}
+	return feeds
+}
+
+// fetchAll mengambil feed secara paralel. Feed yang gagal hanya di-log,
+// error baru dikembalikan jika semua feed gagal.
+func (r *RSSRepository) fetchAll(feeds []string) ([]News, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		news    []News
+		lastErr error
+		failed  int
+	)
+
+	for _, feedURL := range feeds {
+		wg.Add(1)
+		go func(feedURL string) {
+			defer wg.Done()
+
+			items, err := r.fetchFeed(feedURL)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("❌ ERROR: RSS feed %s failed: %v", feedURL, err)
+				lastErr = err
+				failed++
+				return
+			}
+			news = append(news, items...)
+		}(feedURL)
+	}
+	wg.Wait()
+
+	if failed == len(feeds) && lastErr != nil {
+		log.Printf("❌ ERROR: All %d RSS feeds failed", failed)
+		return nil, lastErr
+	}
+
+	return news, nil
+}
+
+func (r *RSSRepository) fetchFeed(feedURL string) ([]News, error) {
+	resp, err := r.client.Get(feedURL)
+	if err != nil {
+		return nil, newSourceError(feedURL, ErrUpstreamDown, 0, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(feedURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newSourceError(feedURL, ErrUpstreamDown, resp.StatusCode, err)
+	}
+
+	news, err := ParseFeed(body)
+	if err != nil {
+		return nil, newSourceError(feedURL, ErrDecodeFailed, resp.StatusCode, err)
+	}
+	return news, nil
+}
+
+// ParseFeed mengubah dokumen RSS 2.0 atau Atom 1.0 menjadi daftar News.
+func ParseFeed(data []byte) ([]News, error) {
+	var doc rssDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode feed: %w", err)
+	}
+
+	switch doc.XMLName.Local {
+	case "rss":
+		return parseRSSItems(doc.Channel), nil
+	case "feed":
+		return parseAtomEntries(doc.Title, feedLanguage(doc.Lang), doc.Entries), nil
+	default:
+		return nil, fmt.Errorf("unsupported feed format <%s>", doc.XMLName.Local)
+	}
+}
+
+func parseRSSItems(channel rssChannel) []News {
+	source := cleanText(channel.Title)
+	language := feedLanguage(channel.Language)
+
+	var news []News
+	for _, item := range channel.Items {
+		link := strings.TrimSpace(item.Link)
+		if link == "" && strings.HasPrefix(item.GUID, "http") {
+			link = strings.TrimSpace(item.GUID)
+		}
+
+		title := cleanText(item.Title)
+		if title == "" || link == "" {
+			continue
+		}
+
+		date := item.PubDate
+		if date == "" {
+			date = item.Date
+		}
+
+		news = append(news, News{
+			Title:       title,
+			Description: cleanText(item.Description),
+			URL:         link,
+			PublishedAt: parseFeedDate(date),
+			Source:      source,
+			ImageURL:    rssItemImage(item),
+			Language:    language,
+		})
+	}
+	return news
+}
+
+// rssItemImage mencari gambar artikel dari media:thumbnail, media:content atau enclosure
+func rssItemImage(item rssItem) string {
+	for _, media := range item.Thumbnails {
+		if media.URL != "" {
+			return media.URL
+		}
+	}
+	for _, media := range item.MediaContent {
+		if media.URL != "" && (media.Medium == "image" || strings.HasPrefix(media.Type, "image/")) {
+			return media.URL
+		}
+	}
+	for _, enclosure := range item.Enclosures {
+		if strings.HasPrefix(enclosure.Type, "image/") {
+			return enclosure.URL
+		}
+	}
+	return ""
+}
+
+func parseAtomEntries(feedTitle, language string, entries []atomEntry) []News {
+	source := cleanText(feedTitle)
+
+	var news []News
+	for _, entry := range entries {
+		link := atomEntryLink(entry.Links)
+		title := cleanText(entry.Title)
+		if title == "" || link == "" {
+			continue
+		}
+
+		description := entry.Summary
+		if description == "" {
+			description = entry.Content
+		}
+
+		date := entry.Published
+		if date == "" {
+			date = entry.Updated
+		}
+
+		news = append(news, News{
+			Title:       title,
+			Description: cleanText(description),
+			URL:         link,
+			PublishedAt: parseFeedDate(date),
+			Source:      source,
+			ImageURL:    atomEntryImage(entry),
+			Language:    language,
+		})
+	}
+	return news
+}
+
+// atomEntryImage mencari gambar dari media:thumbnail atau link rel="enclosure" bertipe gambar
+func atomEntryImage(entry atomEntry) string {
+	for _, media := range entry.Thumbnails {
+		if media.URL != "" {
+			return media.URL
+		}
+	}
+	for _, link := range entry.Links {
+		if link.Rel == "enclosure" && strings.HasPrefix(link.Type, "image/") {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// atomEntryLink memilih link rel="alternate" (atau tanpa rel) sebagai URL artikel
+func atomEntryLink(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	if len(links) > 0 {
+		return strings.TrimSpace(links[0].Href)
+	}
+	return ""
+}
+
+// feedLanguage mengambil kode bahasa ISO 639-1 dari <language> atau xml:lang,
+// misal "en-US" menjadi "en"
+func feedLanguage(value string) string {
+	language, _, _ := strings.Cut(strings.TrimSpace(value), "-")
+	return strings.ToLower(language)
+}
+
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC3339Nano,
+	time.RFC822Z,
+	time.RFC822,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+}
+
+// parseFeedDate mengembalikan waktu nol jika tanggal kosong atau formatnya tidak
+// dikenal, agar artikel tanpa tanggal tidak terlihat selalu baru: artikel ini tidak
+// lolos filter "sejak" dan diurutkan paling akhir
+func parseFeedDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// cleanText membuang tag HTML dan whitespace berlebih dari judul/deskripsi feed
+func cleanText(value string) string {
+	value = htmlTagPattern.ReplaceAllString(value, " ")
+	value = html.UnescapeString(value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func sortNewestFirst(news []News) {
+	sort.SliceStable(news, func(i, j int) bool {
+		return news[i].PublishedAt.After(news[j].PublishedAt)
+	})
+}
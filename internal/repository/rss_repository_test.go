@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// newFeedServer menyajikan file dari testdata; path lain menghasilkan 404
+func newFeedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func TestParseFeedRSS(t *testing.T) {
+	news, err := ParseFeed(readFixture(t, "techcrunch.rss.xml"))
+	if err != nil {
+		t.Fatalf("ParseFeed: %v", err)
+	}
+	if len(news) != 3 {
+		t.Fatalf("got %d items, want 3 (untitled item skipped)", len(news))
+	}
+
+	first := news[0]
+	if first.Title != "OpenAI ships a new model" {
+		t.Errorf("title = %q", first.Title)
+	}
+	if first.Description != "The model is faster and cheaper." {
+		t.Errorf("description = %q", first.Description)
+	}
+	if first.Source != "TechCrunch" || first.Language != "en" {
+		t.Errorf("source/language = %q/%q", first.Source, first.Language)
+	}
+	if first.ImageURL != "https://techcrunch.com/img/openai.jpg" {
+		t.Errorf("image = %q", first.ImageURL)
+	}
+	if want := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC); !first.PublishedAt.Equal(want) {
+		t.Errorf("publishedAt = %v, want %v", first.PublishedAt, want)
+	}
+
+	second := news[1]
+	if second.URL != "https://techcrunch.com/2025/03/09/kubernetes-133/" {
+		t.Errorf("guid fallback URL = %q", second.URL)
+	}
+	if second.ImageURL != "https://techcrunch.com/img/k8s.png" {
+		t.Errorf("enclosure image = %q", second.ImageURL)
+	}
+	if want := time.Date(2025, 3, 9, 18, 0, 0, 0, time.UTC); !second.PublishedAt.Equal(want) {
+		t.Errorf("dc:date = %v, want %v", second.PublishedAt, want)
+	}
+
+	if !news[2].PublishedAt.IsZero() {
+		t.Errorf("undated item publishedAt = %v, want zero", news[2].PublishedAt)
+	}
+}
+
+func TestParseFeedAtom(t *testing.T) {
+	news, err := ParseFeed(readFixture(t, "verge.atom.xml"))
+	if err != nil {
+		t.Fatalf("ParseFeed: %v", err)
+	}
+	if len(news) != 2 {
+		t.Fatalf("got %d entries, want 2", len(news))
+	}
+
+	if news[0].URL != "https://www.theverge.com/2025/3/10/vision-pro-2" {
+		t.Errorf("alternate link = %q", news[0].URL)
+	}
+	if news[0].ImageURL != "https://www.theverge.com/img/vision.jpg" {
+		t.Errorf("enclosure image = %q", news[0].ImageURL)
+	}
+	if news[0].Source != "The Verge" || news[0].Language != "en" {
+		t.Errorf("source/language = %q/%q", news[0].Source, news[0].Language)
+	}
+	if news[1].Description != "More drivers move to Rust." {
+		t.Errorf("content fallback = %q", news[1].Description)
+	}
+	if want := time.Date(2025, 3, 8, 7, 15, 0, 0, time.UTC); !news[1].PublishedAt.Equal(want) {
+		t.Errorf("updated fallback = %v, want %v", news[1].PublishedAt, want)
+	}
+}
+
+func TestParseFeedUnsupported(t *testing.T) {
+	if _, err := ParseFeed([]byte(`<html><body>not a feed</body></html>`)); err == nil {
+		t.Fatal("expected error for non-feed document")
+	}
+}
+
+func TestParseFeedDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"Mon, 10 Mar 2025 09:30:00 +0000", time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)},
+		{"Mon, 3 Mar 2025 09:30:00 +0000", time.Date(2025, 3, 3, 9, 30, 0, 0, time.UTC)},
+		{"2025-03-10T12:00:00Z", time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)},
+		{"2025-03-10T12:00:00", time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)},
+		{"", time.Time{}},
+		{"kemarin sore", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		if got := parseFeedDate(tt.value); !got.Equal(tt.want) {
+			t.Errorf("parseFeedDate(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRSSRepositoryGetLatestNewsSince(t *testing.T) {
+	server := newFeedServer(t)
+	repo := NewRSSRepository([]string{server.URL + "/techcrunch.rss.xml", server.URL + "/verge.atom.xml"})
+
+	news, err := repo.GetLatestNewsSince(time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetLatestNewsSince: %v", err)
+	}
+
+	want := []string{"Apple announces Vision Pro 2", "OpenAI ships a new model", "Kubernetes 1.33 released"}
+	if len(news) != len(want) {
+		t.Fatalf("got %d articles, want %d: %+v", len(news), len(want), news)
+	}
+	for i, title := range want {
+		if news[i].Title != title {
+			t.Errorf("news[%d] = %q, want %q", i, news[i].Title, title)
+		}
+	}
+}
+
+func TestRSSRepositorySearchSortsUndatedLast(t *testing.T) {
+	server := newFeedServer(t)
+	repo := NewRSSRepository([]string{server.URL + "/techcrunch.rss.xml"})
+
+	news, err := repo.SearchNews(SearchOptions{Keyword: "e"})
+	if err != nil {
+		t.Fatalf("SearchNews: %v", err)
+	}
+	if len(news) != 3 {
+		t.Fatalf("got %d results, want 3", len(news))
+	}
+	if last := news[len(news)-1]; last.Title != "Undated press release" {
+		t.Errorf("last result = %q, want undated item", last.Title)
+	}
+
+	news, err = repo.SearchNews(SearchOptions{Keyword: "press release", Since: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("SearchNews with since: %v", err)
+	}
+	if len(news) != 0 {
+		t.Errorf("undated item should not pass --since, got %+v", news)
+	}
+}
+
+func TestRSSRepositoryToleratesFailedFeed(t *testing.T) {
+	server := newFeedServer(t)
+	repo := NewRSSRepository([]string{server.URL + "/missing.xml", server.URL + "/verge.atom.xml"})
+
+	news, err := repo.GetLatestNewsSince(time.Time{})
+	if err != nil {
+		t.Fatalf("one failed feed should not fail the fetch: %v", err)
+	}
+	if len(news) != 2 {
+		t.Errorf("got %d articles, want 2", len(news))
+	}
+}
+
+func TestRSSRepositoryAllFeedsFailed(t *testing.T) {
+	server := newFeedServer(t)
+	repo := NewRSSRepository([]string{server.URL + "/missing.xml"})
+
+	_, err := repo.GetLatestNewsSince(time.Time{})
+	if !errors.Is(err, ErrUpstreamDown) {
+		t.Fatalf("err = %v, want ErrUpstreamDown", err)
+	}
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	schedulesBucket    = []byte("schedules")
+	scheduleRunBucket  = []byte("schedule_runs")
+	scheduleSeedBucket = []byte("schedule_seeds")
+)
+
+// Jenis jadwal: berita terbaru (default) atau digest top story dari riwayat
+const (
+	ScheduleKindNews          = ""
+	ScheduleKindWeeklyDigest  = "weekly-digest"
+	ScheduleKindMonthlyDigest = "monthly-digest"
+)
+
+// ErrScheduleNotFound dikembalikan jika ID jadwal tidak ada di database
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// Schedule adalah satu jadwal berita otomatis yang dijalankan oleh cron service.
+// ChannelID kosong berarti dikirim ke news channel setiap guild (lihat GuildConfig);
+// Query yang diisi membuat jadwal mengirim hasil pencarian alih-alih berita terbaru;
+// Kind digest mengirim ranking top story dari riwayat artikel.
+// CronExpr dievaluasi di Timezone (nama IANA); kosong berarti mengikuti zona waktu
+// guild atau zona default bot.
+type Schedule struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CronExpr  string    `json:"cron"`
+	Timezone  string    `json:"timezone,omitempty"`
+	GuildID   string    `json:"guildId,omitempty"`
+	ChannelID string    `json:"channelId,omitempty"`
+	Header    string    `json:"header"`
+	Query     string    `json:"query,omitempty"`
+	Kind      string    `json:"kind,omitempty"`
+	Paused    bool      `json:"paused"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ScheduleRepository interface {
+	ListSchedules() ([]Schedule, error)
+	GetSchedule(id string) (*Schedule, error)
+	SaveSchedule(schedule *Schedule) error
+	DeleteSchedule(id string) error
+	// LastSuccess mengembalikan waktu run sukses terakhir jadwal di satu zona waktu;
+	// waktu nol berarti belum pernah sukses
+	LastSuccess(scheduleID, timezone string) (time.Time, error)
+	RecordSuccess(scheduleID, timezone string, at time.Time) error
+}
+
+type BoltScheduleRepository struct {
+	db *bolt.DB
+}
+
+// NewBoltScheduleRepository membuka bucket jadwal. Setiap jadwal di defaults
+// disimpan sekali saja (dicatat di bucket "schedule_seeds"), sehingga default baru
+// ikut muncul setelah upgrade, tetapi default yang dihapus admin tidak kembali.
+//
+// Database lama yang sudah punya jadwal tetapi belum punya bucket seed tidak bisa
+// membedakan default yang dihapus admin dari default yang belum pernah disimpan.
+// Default aktif di sana hanya ditandai sudah di-seed; default yang di-pause (opt-in)
+// tetap disimpan karena tidak berjalan sebelum admin mengaktifkannya.
+func NewBoltScheduleRepository(db *bolt.DB, defaults []Schedule) (*BoltScheduleRepository, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		newRunBucket := tx.Bucket(scheduleRunBucket) == nil
+		legacyDB := tx.Bucket(schedulesBucket) != nil && tx.Bucket(scheduleSeedBucket) == nil
+		for _, name := range [][]byte{schedulesBucket, scheduleRunBucket, scheduleSeedBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		bucket := tx.Bucket(schedulesBucket)
+		seeds := tx.Bucket(scheduleSeedBucket)
+
+		now := time.Now()
+		if newRunBucket {
+			if err := seedLastSuccess(bucket, tx.Bucket(scheduleRunBucket), now); err != nil {
+				return err
+			}
+		}
+
+		for i, schedule := range defaults {
+			if seeds.Get([]byte(schedule.ID)) != nil {
+				continue
+			}
+
+			if bucket.Get([]byte(schedule.ID)) == nil && !(legacyDB && !schedule.Paused) {
+				// Geser sedikit agar urutan default tetap terjaga saat di-list
+				schedule.CreatedAt = now.Add(time.Duration(i) * time.Millisecond)
+				if err := putJSON(bucket, schedule.ID, schedule); err != nil {
+					return err
+				}
+			}
+			if err := seeds.Put([]byte(schedule.ID), []byte(now.Format(time.RFC3339))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoltScheduleRepository{db: db}, nil
+}
+
+// ListSchedules mengembalikan semua jadwal, urut berdasarkan waktu dibuat
+func (r *BoltScheduleRepository) ListSchedules() ([]Schedule, error) {
+	var schedules []Schedule
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(schedulesBucket)
+		return bucket.ForEach(func(key, value []byte) error {
+			var schedule Schedule
+			if _, err := getJSON(bucket, string(key), &schedule); err != nil {
+				return err
+			}
+			schedules = append(schedules, schedule)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+	})
+	return schedules, nil
+}
+
+func (r *BoltScheduleRepository) GetSchedule(id string) (*Schedule, error) {
+	var schedule Schedule
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		found, err := getJSON(tx.Bucket(schedulesBucket), id, &schedule)
+		if err == nil && !found {
+			return ErrScheduleNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *BoltScheduleRepository) SaveSchedule(schedule *Schedule) error {
+	if schedule.CreatedAt.IsZero() {
+		schedule.CreatedAt = time.Now()
+	}
+
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(schedulesBucket), schedule.ID, schedule)
+	})
+}
+
+func (r *BoltScheduleRepository) DeleteSchedule(id string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(schedulesBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return ErrScheduleNotFound
+		}
+
+		// Hapus juga catatan run sukses jadwal ini di semua zona waktu
+		runs := tx.Bucket(scheduleRunBucket).Cursor()
+		prefix := []byte(id + "|")
+		for key, _ := runs.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = runs.Seek(prefix) {
+			if err := runs.Delete(); err != nil {
+				return err
+			}
+		}
+
+		return bucket.Delete([]byte(id))
+	})
+}
+
+// LastSuccess memakai catatan baseline jadwal (lihat seedLastSuccess) jika jadwal
+// belum pernah sukses di zona waktu tersebut
+func (r *BoltScheduleRepository) LastSuccess(scheduleID, timezone string) (time.Time, error) {
+	var at time.Time
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		runs := tx.Bucket(scheduleRunBucket)
+		found, err := getJSON(runs, scheduleRunKey(scheduleID, timezone), &at)
+		if err != nil || found {
+			return err
+		}
+		_, err = getJSON(runs, scheduleRunKey(scheduleID, ""), &at)
+		return err
+	})
+	return at, err
+}
+
+func (r *BoltScheduleRepository) RecordSuccess(scheduleID, timezone string, at time.Time) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return putJSON(tx.Bucket(scheduleRunBucket), scheduleRunKey(scheduleID, timezone), at)
+	})
+}
+
+// seedLastSuccess dijalankan sekali saat bucket "schedule_runs" baru dibuat. Jadwal
+// yang sudah ada sebelum catch-up tersedia belum punya catatan run sukses, padahal
+// run terakhirnya sudah terkirim; tanpa baseline, restart pertama dalam grace window
+// akan mengirim ulang run itu sebagai kiriman tertunda.
+func seedLastSuccess(schedules, runs *bolt.Bucket, at time.Time) error {
+	return schedules.ForEach(func(key, value []byte) error {
+		return putJSON(runs, scheduleRunKey(string(key), ""), at)
+	})
+}
+
+// scheduleRunKey adalah key run sukses per jadwal dan zona waktu; timezone kosong
+// adalah baseline yang berlaku untuk semua zona
+func scheduleRunKey(scheduleID, timezone string) string {
+	return scheduleID + "|" + timezone
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestScheduleRepositorySeedsLastSuccessForExistingSchedules(t *testing.T) {
+	db := openTestDB(t)
+
+	// Database dari versi sebelum catch-up: jadwal sudah ada, bucket run belum
+	legacy := Schedule{ID: "morning", Name: "morning", CronExpr: "0 1 * * *", CreatedAt: time.Now().AddDate(0, -1, 0)}
+	err := db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucket(schedulesBucket)
+		if err != nil {
+			return err
+		}
+		return putJSON(bucket, legacy.ID, legacy)
+	})
+	if err != nil {
+		t.Fatalf("seed legacy database: %v", err)
+	}
+
+	before := time.Now()
+	repo, err := NewBoltScheduleRepository(db, []Schedule{{ID: "evening", Name: "evening", CronExpr: "0 17 * * *"}})
+	if err != nil {
+		t.Fatalf("NewBoltScheduleRepository: %v", err)
+	}
+
+	baseline, err := repo.LastSuccess("morning", "Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("LastSuccess: %v", err)
+	}
+	if baseline.Before(before.Truncate(time.Second)) {
+		t.Errorf("legacy schedule last success = %v, want baseline at upgrade time", baseline)
+	}
+
+	newDefault, err := repo.LastSuccess("evening", "Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("LastSuccess: %v", err)
+	}
+	if !newDefault.IsZero() {
+		t.Errorf("schedule added with the upgrade should have no baseline, got %v", newDefault)
+	}
+
+	ranAt := baseline.Add(time.Hour)
+	if err := repo.RecordSuccess("morning", "Asia/Jakarta", ranAt); err != nil {
+		t.Fatalf("RecordSuccess: %v", err)
+	}
+	if got, _ := repo.LastSuccess("morning", "Asia/Jakarta"); !got.Equal(ranAt) {
+		t.Errorf("recorded run = %v, want %v", got, ranAt)
+	}
+	if got, _ := repo.LastSuccess("morning", "Asia/Makassar"); !got.Equal(baseline) {
+		t.Errorf("other timezone = %v, want baseline %v", got, baseline)
+	}
+
+	// Membuka ulang database tidak menggeser baseline
+	if _, err := NewBoltScheduleRepository(db, nil); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if got, _ := repo.LastSuccess("morning", "Asia/Makassar"); !got.Equal(baseline) {
+		t.Errorf("baseline after reopen = %v, want %v", got, baseline)
+	}
+
+	if err := repo.DeleteSchedule("morning"); err != nil {
+		t.Fatalf("DeleteSchedule: %v", err)
+	}
+	if got, _ := repo.LastSuccess("morning", "Asia/Makassar"); !got.IsZero() {
+		t.Errorf("baseline should be deleted with the schedule, got %v", got)
+	}
+}
+
+func TestScheduleRepositorySeedsDefaultsOnce(t *testing.T) {
+	defaults := []Schedule{
+		{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"},
+		{ID: "weekly-digest", Name: "weekly-digest", CronExpr: "0 16 * * 5", Kind: ScheduleKindWeeklyDigest, Paused: true},
+	}
+
+	t.Run("fresh database", func(t *testing.T) {
+		db := openTestDB(t)
+		repo, err := NewBoltScheduleRepository(db, defaults)
+		if err != nil {
+			t.Fatalf("NewBoltScheduleRepository: %v", err)
+		}
+		if err := repo.DeleteSchedule("morning"); err != nil {
+			t.Fatalf("DeleteSchedule: %v", err)
+		}
+
+		repo, err = NewBoltScheduleRepository(db, defaults)
+		if err != nil {
+			t.Fatalf("reopen: %v", err)
+		}
+		schedules, err := repo.ListSchedules()
+		if err != nil {
+			t.Fatalf("ListSchedules: %v", err)
+		}
+		if len(schedules) != 1 || schedules[0].ID != "weekly-digest" || !schedules[0].Paused {
+			t.Fatalf("schedules = %+v, want only the paused weekly digest", schedules)
+		}
+	})
+
+	t.Run("database from before seeding was tracked", func(t *testing.T) {
+		db := openTestDB(t)
+
+		// Admin sudah menghapus "morning" sebelum bucket seed ada
+		custom := Schedule{ID: "standup", Name: "standup", CronExpr: "0 9 * * 1-5"}
+		err := db.Update(func(tx *bolt.Tx) error {
+			bucket, err := tx.CreateBucket(schedulesBucket)
+			if err != nil {
+				return err
+			}
+			return putJSON(bucket, custom.ID, custom)
+		})
+		if err != nil {
+			t.Fatalf("seed legacy database: %v", err)
+		}
+
+		repo, err := NewBoltScheduleRepository(db, defaults)
+		if err != nil {
+			t.Fatalf("NewBoltScheduleRepository: %v", err)
+		}
+		if _, err := repo.GetSchedule("morning"); !errors.Is(err, ErrScheduleNotFound) {
+			t.Errorf("deleted default was seeded again: %v", err)
+		}
+		digest, err := repo.GetSchedule("weekly-digest")
+		if err != nil || !digest.Paused {
+			t.Errorf("opt-in digest = %+v, %v; want it stored paused", digest, err)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"net/url"
+	"strings"
+)
+
+// trackingParams adalah query parameter yang tidak mengubah isi halaman
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true,
+	"mc_cid": true, "mc_eid": true, "ref": true, "ref_src": true,
+	"cmpid": true, "ncid": true, "sr_share": true, "amp": true,
+	"outputtype": true, "guccounter": true, "guce_referrer": true, "guce_referrer_sig": true,
+}
+
+// CanonicalizeURL menormalkan URL artikel: membuang tracking parameter (utm_*,
+// fbclid, ...), fragment, varian AMP dan "www.", sehingga URL yang sama dari
+// sumber berbeda menghasilkan string yang sama. Hasilnya hanya untuk kunci
+// dedup dan riwayat, bukan untuk ditampilkan ke user.
+func CanonicalizeURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	// Google AMP cache: https://www.google.com/amp/s/example.com/story
+	if strings.Contains(u.Host, "google.") && strings.HasPrefix(u.Path, "/amp/s/") {
+		return CanonicalizeURL("https://" + strings.TrimPrefix(u.Path, "/amp/s/"))
+	}
+
+	u.Host = strings.TrimPrefix(u.Host, "www.")
+	u.Host = strings.TrimPrefix(u.Host, "amp.")
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.Path = strings.TrimSuffix(u.Path, "/amp")
+	u.Path = strings.Replace(u.Path, "/amp/", "/", 1)
+	u.Path = strings.Replace(u.Path, ".amp.html", ".html", 1)
+	u.RawPath = ""
+
+	query := u.Query()
+	for key := range query {
+		keyLower := strings.ToLower(key)
+		if strings.HasPrefix(keyLower, "utm_") || trackingParams[keyLower] {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode() // Encode mengurutkan key secara otomatis
+
+	return u.String()
+}
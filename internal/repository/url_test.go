@@ -0,0 +1,27 @@
+package repository
+
+import "testing"
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://techcrunch.com/2025/03/10/story/", "https://techcrunch.com/2025/03/10/story"},
+		{"http://www.TechCrunch.com/2025/03/10/story", "https://techcrunch.com/2025/03/10/story"},
+		{"https://techcrunch.com/story?utm_source=twitter&utm_medium=social#comments", "https://techcrunch.com/story"},
+		{"https://example.com/story?id=42&fbclid=abc", "https://example.com/story?id=42"},
+		{"https://example.com/story?b=2&a=1", "https://example.com/story?a=1&b=2"},
+		{"https://amp.example.com/story/amp", "https://example.com/story"},
+		{"https://example.com/amp/story", "https://example.com/story"},
+		{"https://example.com/story.amp.html", "https://example.com/story.html"},
+		{"https://www.google.com/amp/s/example.com/story/", "https://example.com/story"},
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalizeURL(tt.raw); got != tt.want {
+			t.Errorf("CanonicalizeURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	watchesBucket   = []byte("watches")
+	watchSentBucket = []byte("watch_sent")
+)
+
+// ErrWatchLimit dikembalikan jika user sudah mencapai batas jumlah kata kunci
+var ErrWatchLimit = errors.New("watch limit reached")
+
+// WatchTerm adalah satu kata kunci yang dipantau user. Hanya artikel yang terbit
+// setelah CreatedAt yang dikirim, agar watch baru tidak mengirim berita lama.
+type WatchTerm struct {
+	Term      string    `json:"term"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserWatches adalah daftar kata kunci milik satu user
+type UserWatches struct {
+	UserID string      `json:"userId"`
+	Terms  []WatchTerm `json:"terms"`
+}
+
+type WatchRepository interface {
+	// AddWatch mengembalikan false jika term sudah ada, atau ErrWatchLimit jika
+	// user sudah punya limit kata kunci
+	AddWatch(userID, term string, limit int) (bool, error)
+	// RemoveWatch mengembalikan false jika term tidak ditemukan
+	RemoveWatch(userID, term string) (bool, error)
+	ListWatches(userID string) ([]WatchTerm, error)
+	AllWatches() ([]UserWatches, error)
+	// FilterUnsent membuang artikel yang sudah pernah dikirim ke user
+	FilterUnsent(userID string, news []News) ([]News, error)
+	RecordSent(userID string, news []News, sentAt time.Time) error
+	// PruneSent menghapus catatan kiriman sebelum waktu before
+	PruneSent(before time.Time) (int, error)
+}
+
+// BoltWatchRepository menyimpan watchlist per user ID di bucket "watches" dan
+// artikel yang sudah dikirim di bucket "watch_sent" dengan sub-bucket per user ID
+type BoltWatchRepository struct {
+	db *bolt.DB
+}
+
+func NewBoltWatchRepository(db *bolt.DB) (*BoltWatchRepository, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{watchesBucket, watchSentBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoltWatchRepository{db: db}, nil
+}
+
+func (r *BoltWatchRepository) AddWatch(userID, term string, limit int) (bool, error) {
+	added := false
+
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(watchesBucket)
+
+		watches := UserWatches{UserID: userID}
+		if _, err := getJSON(bucket, userID, &watches); err != nil {
+			return err
+		}
+
+		for _, existing := range watches.Terms {
+			if existing.Term == term {
+				return nil
+			}
+		}
+		if limit > 0 && len(watches.Terms) >= limit {
+			return ErrWatchLimit
+		}
+
+		watches.Terms = append(watches.Terms, WatchTerm{Term: term, CreatedAt: time.Now()})
+		added = true
+		return putJSON(bucket, userID, watches)
+	})
+
+	return added, err
+}
+
+func (r *BoltWatchRepository) RemoveWatch(userID, term string) (bool, error) {
+	removed := false
+
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(watchesBucket)
+
+		var watches UserWatches
+		found, err := getJSON(bucket, userID, &watches)
+		if err != nil || !found {
+			return err
+		}
+
+		terms := watches.Terms[:0]
+		for _, existing := range watches.Terms {
+			if existing.Term == term {
+				removed = true
+				continue
+			}
+			terms = append(terms, existing)
+		}
+		if !removed {
+			return nil
+		}
+
+		// User tanpa watch tersisa dihapus beserta catatan kirimannya
+		if len(terms) == 0 {
+			if err := tx.Bucket(watchSentBucket).DeleteBucket([]byte(userID)); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+			return bucket.Delete([]byte(userID))
+		}
+
+		watches.Terms = terms
+		return putJSON(bucket, userID, watches)
+	})
+
+	return removed, err
+}
+
+func (r *BoltWatchRepository) ListWatches(userID string) ([]WatchTerm, error) {
+	var watches UserWatches
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		_, err := getJSON(tx.Bucket(watchesBucket), userID, &watches)
+		return err
+	})
+
+	return watches.Terms, err
+}
+
+func (r *BoltWatchRepository) AllWatches() ([]UserWatches, error) {
+	var all []UserWatches
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(watchesBucket).ForEach(func(key, value []byte) error {
+			var watches UserWatches
+			if err := json.Unmarshal(value, &watches); err != nil {
+				return err
+			}
+			all = append(all, watches)
+			return nil
+		})
+	})
+
+	return all, err
+}
+
+func (r *BoltWatchRepository) FilterUnsent(userID string, news []News) ([]News, error) {
+	var unsent []News
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		userBucket := tx.Bucket(watchSentBucket).Bucket([]byte(userID))
+		for _, article := range news {
+			if userBucket != nil && userBucket.Get([]byte(CanonicalizeURL(article.URL))) != nil {
+				continue
+			}
+			unsent = append(unsent, article)
+		}
+		return nil
+	})
+
+	return unsent, err
+}
+
+func (r *BoltWatchRepository) RecordSent(userID string, news []News, sentAt time.Time) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		userBucket, err := tx.Bucket(watchSentBucket).CreateBucketIfNotExists([]byte(userID))
+		if err != nil {
+			return err
+		}
+
+		for _, article := range news {
+			if err := putJSON(userBucket, CanonicalizeURL(article.URL), sentAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *BoltWatchRepository) PruneSent(before time.Time) (int, error) {
+	pruned := 0
+
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		sent := tx.Bucket(watchSentBucket)
+		return sent.ForEachBucket(func(userID []byte) error {
+			userBucket := sent.Bucket(userID)
+
+			var stale [][]byte
+			err := userBucket.ForEach(func(key, value []byte) error {
+				var sentAt time.Time
+				if err := json.Unmarshal(value, &sentAt); err != nil {
+					return err
+				}
+				if sentAt.Before(before) {
+					stale = append(stale, append([]byte(nil), key...))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, key := range stale {
+				if err := userBucket.Delete(key); err != nil {
+					return err
+				}
+			}
+			pruned += len(stale)
+			return nil
+		})
+	})
+
+	return pruned, err
+}
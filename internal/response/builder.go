@@ -63,6 +63,18 @@ func NewStatusResponse() *Builder {
 	}
 }
 
+// NewHelpResponse creates a new help response builder
+func NewHelpResponse() *Builder {
+	return &Builder{
+		response: &HelpResponse{
+			BaseResponse: BaseResponse{
+				Success:   true,
+				Timestamp: time.Now(),
+			},
+		},
+	}
+}
+
 // NewErrorResponse creates a new error response
 func NewErrorResponse(code, message string) *Builder {
 	return &Builder{
@@ -103,6 +115,32 @@ func (b *Builder) WithSearchResults(results []repository.News, count int) *Build
 	return b
 }
 
+// WithCacheInfo records whether the results came from the news cache and how long
+// fetching them took. Call after WithNews or WithSearchResults.
+func (b *Builder) WithCacheInfo(cacheHit bool, responseTime time.Duration) *Builder {
+	var meta *MetaInfo
+	switch resp := b.response.(type) {
+	case *NewsResponse:
+		meta = resp.Meta
+	case *SearchResponse:
+		meta = resp.Meta
+	}
+	if meta != nil {
+		meta.CacheHit = cacheHit
+		meta.ResponseTime = responseTime.Round(time.Millisecond).String()
+	}
+	return b
+}
+
+// WithCommands sets the command list and usage examples of a help response
+func (b *Builder) WithCommands(commands []CommandInfo, examples []string) *Builder {
+	if resp, ok := b.response.(*HelpResponse); ok {
+		resp.Commands = commands
+		resp.Examples = examples
+	}
+	return b
+}
+
 // WithMessage sets the response message
 func (b *Builder) WithMessage(message string) *Builder {
 	switch resp := b.response.(type) {
@@ -214,7 +252,10 @@ func ConvertToNewsItems(news []repository.News) []NewsItem {
 			URL:         article.URL,
 			PublishedAt: article.PublishedAt,
 			Source:      article.Source,
-			Score:       0, // Default score since repository.News doesn't have Score field
+			Score:       article.Score,
+			Comments:    article.Comments,
+			Related:     article.RelatedSources,
+			ImageURL:    article.ImageURL,
 			Category:    "Technology",
 			Tags:        extractTags(article.Title + " " + article.Description),
 			TimeAgo:     TimeAgo(article.PublishedAt),
@@ -255,6 +296,10 @@ func extractTags(content string) []string {
 
 // TimeAgo returns a human-readable time difference
 func TimeAgo(t time.Time) string {
+	if t.IsZero() {
+		return "Tanggal tidak diketahui"
+	}
+
 	now := time.Now()
 	diff := now.Sub(t)
 
@@ -0,0 +1,73 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const EmbedColorDigest = 0x9B59B6
+
+// DigestEntry adalah satu top story dalam digest beserta statistik ranking-nya
+type DigestEntry struct {
+	Title     string
+	URL       string
+	Source    string
+	Posts     int
+	Reactions int
+	Score     int
+}
+
+// DigestSection adalah kelompok top story dalam satu topik
+type DigestSection struct {
+	Topic   string
+	Entries []DigestEntry
+}
+
+// RenderDigest membuat satu embed per topik; nomor urut story berlanjut antar topik
+func (r *EmbedRenderer) RenderDigest(header string, sections []DigestSection) *DiscordMessage {
+	if len(sections) == 0 {
+		return TextMessage(header + "\n\n📭 Belum ada cukup riwayat berita untuk periode ini.")
+	}
+
+	// Discord membatasi 10 embed per pesan
+	if len(sections) > 10 {
+		sections = sections[:10]
+	}
+
+	rank := 1
+	embeds := make([]*discordgo.MessageEmbed, 0, len(sections))
+	for _, section := range sections {
+		var description strings.Builder
+		for _, entry := range section.Entries {
+			fmt.Fprintf(&description, "**%d.** [%s](%s)\n%s\n", rank, truncate(entry.Title, 120), entry.URL, digestStats(entry))
+			rank++
+		}
+
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       section.Topic,
+			Description: description.String(),
+			Color:       EmbedColorDigest,
+		})
+	}
+
+	return &DiscordMessage{
+		Content: header,
+		Embeds:  embeds,
+	}
+}
+
+func digestStats(entry DigestEntry) string {
+	stats := []string{"📰 " + entry.Source}
+	if entry.Posts > 0 {
+		stats = append(stats, fmt.Sprintf("📢 %dx dikirim", entry.Posts))
+	}
+	if entry.Reactions > 0 {
+		stats = append(stats, fmt.Sprintf("👍 %d reaksi", entry.Reactions))
+	}
+	if entry.Score > 0 {
+		stats = append(stats, fmt.Sprintf("⭐ %d points", entry.Score))
+	}
+	return strings.Join(stats, " • ")
+}
@@ -0,0 +1,240 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// MaxEmbedArticles adalah jumlah artikel (satu embed per artikel) dalam satu pesan
+const MaxEmbedArticles = 5
+
+const (
+	EmbedColorNews   = 0xFF6B35
+	EmbedColorSearch = 0x3498DB
+
+	embedTitleLimit       = 256
+	embedDescriptionLimit = 300
+)
+
+// Custom ID tombol navigasi halaman
+const (
+	PagePrevButtonID = "page_prev"
+	PageNextButtonID = "page_next"
+)
+
+// RunCommandButtonPrefix adalah awalan custom ID tombol yang menjalankan command,
+// diikuti nama command dan argumennya, misal "run_cmd:search AI"
+const RunCommandButtonPrefix = "run_cmd:"
+
+// customIDLimit adalah panjang maksimal custom ID komponen Discord
+const customIDLimit = 100
+
+// RunCommandButton membuat tombol yang menjalankan command beserta argumennya.
+// false jika argumen terlalu panjang untuk muat di custom ID.
+func RunCommandButton(name, args string) (discordgo.Button, bool) {
+	command := strings.TrimSpace(name + " " + args)
+	if len(RunCommandButtonPrefix+command) > customIDLimit {
+		return discordgo.Button{}, false
+	}
+
+	label := "▶ /" + command
+	if len([]rune(label)) > 80 {
+		label = string([]rune(label)[:79]) + "…"
+	}
+
+	return discordgo.Button{
+		Label:    label,
+		Style:    discordgo.PrimaryButton,
+		CustomID: RunCommandButtonPrefix + command,
+	}, true
+}
+
+// ParseRunCommandID mengambil nama command dan argumen dari custom ID RunCommandButton
+func ParseRunCommandID(customID string) (name, args string, ok bool) {
+	command, ok := strings.CutPrefix(customID, RunCommandButtonPrefix)
+	if !ok || command == "" {
+		return "", "", false
+	}
+	name, args, _ = strings.Cut(command, " ")
+	return name, args, true
+}
+
+// DiscordMessage adalah pesan siap kirim ke Discord: teks biasa dan/atau embed
+type DiscordMessage struct {
+	Content    string
+	Embeds     []*discordgo.MessageEmbed
+	Components []discordgo.MessageComponent
+	// Pages diisi jika pesan punya lebih dari satu halaman; state-nya disimpan
+	// server-side setelah pesan terkirim agar tombol Previous/Next bisa dipakai
+	Pages *PageSet
+}
+
+// PageSet adalah daftar artikel lengkap yang ditampilkan per halaman
+type PageSet struct {
+	Header string
+	Items  []NewsItem
+	Color  int
+	Page   int
+}
+
+// PageCount mengembalikan jumlah halaman dengan MaxEmbedArticles artikel per halaman
+func (p *PageSet) PageCount() int {
+	return (len(p.Items) + MaxEmbedArticles - 1) / MaxEmbedArticles
+}
+
+// ClampPage membatasi nomor halaman ke rentang 0 sampai PageCount()-1
+func (p *PageSet) ClampPage(page int) int {
+	return max(0, min(page, p.PageCount()-1))
+}
+
+// TextMessage membuat DiscordMessage yang hanya berisi teks
+func TextMessage(content string) *DiscordMessage {
+	return &DiscordMessage{Content: content}
+}
+
+// ToMessageSend mengubah pesan ke format yang dipakai ChannelMessageSendComplex
+func (m *DiscordMessage) ToMessageSend() *discordgo.MessageSend {
+	return &discordgo.MessageSend{
+		Content:    m.Content,
+		Embeds:     m.Embeds,
+		Components: m.Components,
+	}
+}
+
+// EmbedRenderer renders news responses as Discord embeds
+type EmbedRenderer struct{}
+
+// NewEmbedRenderer creates a new embed renderer
+func NewEmbedRenderer() *EmbedRenderer {
+	return &EmbedRenderer{}
+}
+
+// RenderNewsResponse renders a NewsResponse as a paged message with one embed per article
+func (r *EmbedRenderer) RenderNewsResponse(resp *NewsResponse) *DiscordMessage {
+	return r.RenderPage(&PageSet{
+		Header: "📰 **Tech News Update - Berita Teknologi Terbaru**",
+		Items:  resp.News,
+		Color:  EmbedColorNews,
+	})
+}
+
+// RenderSearchResponse renders a SearchResponse as a paged message with one embed per result
+func (r *EmbedRenderer) RenderSearchResponse(resp *SearchResponse) *DiscordMessage {
+	return r.RenderPage(&PageSet{
+		Header: fmt.Sprintf("🔍 **Hasil Pencarian: \"%s\"**\n📊 Ditemukan **%d artikel** yang relevan", resp.Query, resp.ResultCount),
+		Items:  resp.Results,
+		Color:  EmbedColorSearch,
+	})
+}
+
+// RenderPage renders halaman aktif dari PageSet beserta tombol Previous/Next.
+// pages tidak diubah; Pages di pesan hasilnya adalah salinan dengan halaman yang sudah dibatasi.
+func (r *EmbedRenderer) RenderPage(source *PageSet) *DiscordMessage {
+	pages := *source
+	pages.Page = pages.ClampPage(pages.Page)
+	totalPages := pages.PageCount()
+
+	start := pages.Page * MaxEmbedArticles
+	end := min(start+MaxEmbedArticles, len(pages.Items))
+
+	message := &DiscordMessage{
+		Content: pages.Header,
+		Embeds:  r.RenderItems(pages.Items[start:end], pages.Color),
+	}
+
+	if totalPages > 1 {
+		message.Content += fmt.Sprintf("\n📄 Halaman %d/%d", pages.Page+1, totalPages)
+		message.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "◀ Previous",
+						Style:    discordgo.SecondaryButton,
+						CustomID: PagePrevButtonID,
+						Disabled: pages.Page == 0,
+					},
+					discordgo.Button{
+						Label:    "Next ▶",
+						Style:    discordgo.SecondaryButton,
+						CustomID: PageNextButtonID,
+						Disabled: pages.Page == totalPages-1,
+					},
+				},
+			},
+		}
+		message.Pages = &pages
+	}
+
+	return message
+}
+
+// RenderItems membuat embed untuk maksimal MaxEmbedArticles artikel
+func (r *EmbedRenderer) RenderItems(items []NewsItem, color int) []*discordgo.MessageEmbed {
+	if len(items) > MaxEmbedArticles {
+		items = items[:MaxEmbedArticles]
+	}
+
+	embeds := make([]*discordgo.MessageEmbed, 0, len(items))
+	for _, item := range items {
+		embeds = append(embeds, r.renderItem(item, color))
+	}
+	return embeds
+}
+
+func (r *EmbedRenderer) renderItem(item NewsItem, color int) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       truncate(item.Title, embedTitleLimit),
+		URL:         item.URL,
+		Description: truncate(item.Description, embedDescriptionLimit),
+		Color:       color,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: r.footerText(item),
+		},
+	}
+
+	if !item.PublishedAt.IsZero() {
+		embed.Timestamp = item.PublishedAt.Format(time.RFC3339)
+	}
+
+	if item.ImageURL != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: item.ImageURL}
+	}
+
+	if len(item.Tags) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🏷️ Tags",
+			Value:  strings.Join(item.Tags[:min(3, len(item.Tags))], ", "),
+			Inline: true,
+		})
+	}
+
+	if len(item.Related) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🗞️ Juga diliput oleh",
+			Value:  strings.Join(item.Related, ", "),
+			Inline: true,
+		})
+	}
+
+	return embed
+}
+
+func (r *EmbedRenderer) footerText(item NewsItem) string {
+	footer := "📰 " + item.Source
+	if item.Score > 0 {
+		footer += fmt.Sprintf(" • ⭐ %d points • 💬 %d", item.Score, item.Comments)
+	}
+	return footer
+}
+
+// truncate memotong teks berdasarkan jumlah rune agar karakter multibyte tidak rusak
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
@@ -0,0 +1,45 @@
+package response
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestRenderPageClampsWithoutMutating(t *testing.T) {
+	pages := &PageSet{Header: "News", Items: make([]NewsItem, 12), Page: 7}
+	renderer := NewEmbedRenderer()
+
+	message := renderer.RenderPage(pages)
+	if pages.Page != 7 {
+		t.Errorf("input page changed to %d", pages.Page)
+	}
+	if message.Pages == nil || message.Pages == pages || message.Pages.Page != 2 {
+		t.Fatalf("message pages = %+v, want clamped copy on page 2", message.Pages)
+	}
+	if len(message.Embeds) != 2 {
+		t.Errorf("last page embeds = %d, want 2", len(message.Embeds))
+	}
+	if !strings.Contains(message.Content, "Halaman 3/3") {
+		t.Errorf("content = %q", message.Content)
+	}
+
+	buttons := message.Components[0].(discordgo.ActionsRow).Components
+	if prev := buttons[0].(discordgo.Button); prev.Disabled {
+		t.Error("previous button should be enabled on the last page")
+	}
+	if next := buttons[1].(discordgo.Button); !next.Disabled {
+		t.Error("next button should be disabled on the last page")
+	}
+}
+
+func TestRenderPageSinglePage(t *testing.T) {
+	message := NewEmbedRenderer().RenderPage(&PageSet{Items: make([]NewsItem, 3), Page: -1})
+	if message.Pages != nil || len(message.Components) != 0 {
+		t.Errorf("single page should have no pagination state or buttons: %+v", message)
+	}
+	if len(message.Embeds) != 3 {
+		t.Errorf("embeds = %d, want 3", len(message.Embeds))
+	}
+}
@@ -2,6 +2,7 @@ package response
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -47,11 +48,19 @@ func (f *DiscordFormatter) FormatNewsResponse(resp *NewsResponse) string {
 		result.WriteString(fmt.Sprintf("🔗 [Baca Selengkapnya](%s)\n", article.URL))
 		result.WriteString(fmt.Sprintf("📅 %s • 📰 %s", article.TimeAgo, article.Source))
 
+		if article.Score > 0 {
+			result.WriteString(fmt.Sprintf(" • ⭐ %d points • 💬 %d", article.Score, article.Comments))
+		}
+
 		// Add tags if available
 		if len(article.Tags) > 0 {
 			result.WriteString(fmt.Sprintf(" • 🏷️ %s", strings.Join(article.Tags[:min(3, len(article.Tags))], ", ")))
 		}
 
+		if len(article.Related) > 0 {
+			result.WriteString(fmt.Sprintf("\n🗞️ Juga diliput oleh: %s", strings.Join(article.Related, ", ")))
+		}
+
 		result.WriteString("\n\n")
 	}
 
@@ -98,11 +107,19 @@ func (f *DiscordFormatter) FormatSearchResponse(resp *SearchResponse) string {
 		result.WriteString(fmt.Sprintf("🔗 [Baca Selengkapnya](%s)\n", article.URL))
 		result.WriteString(fmt.Sprintf("📅 %s • 📰 %s", article.TimeAgo, article.Source))
 
+		if article.Score > 0 {
+			result.WriteString(fmt.Sprintf(" • ⭐ %d points • 💬 %d", article.Score, article.Comments))
+		}
+
 		// Add tags if available
 		if len(article.Tags) > 0 {
 			result.WriteString(fmt.Sprintf(" • 🏷️ %s", strings.Join(article.Tags[:min(3, len(article.Tags))], ", ")))
 		}
 
+		if len(article.Related) > 0 {
+			result.WriteString(fmt.Sprintf("\n🗞️ Juga diliput oleh: %s", strings.Join(article.Related, ", ")))
+		}
+
 		result.WriteString("\n\n")
 	}
 
@@ -129,8 +146,6 @@ func (f *DiscordFormatter) FormatBotResponse(resp *BotResponse) string {
 		return "Hello! 👋 Saya adalah **AI Tech News Bot**\n\n🤖 Saya bisa membantu Anda mendapatkan berita teknologi terbaru!\n\n💡 Ketik `help` untuk melihat command yang tersedia."
 	case "ping":
 		return "🏓 Pong! Bot sedang online dan siap melayani!"
-	case "help", "bantuan":
-		return f.getHelpMessage()
 	default:
 		if resp.Message != "" {
 			return resp.Message
@@ -150,7 +165,13 @@ func (f *DiscordFormatter) FormatStatusResponse(resp *StatusResponse) string {
 
 	if resp.Services != nil {
 		result.WriteString("🔄 **Services**:\n")
-		for service, status := range resp.Services {
+		services := make([]string, 0, len(resp.Services))
+		for service := range resp.Services {
+			services = append(services, service)
+		}
+		sort.Strings(services)
+		for _, service := range services {
+			status := resp.Services[service]
 			emoji := "✅"
 			if status != "online" && status != "healthy" {
 				emoji = "❌"
@@ -193,30 +214,39 @@ func (f *DiscordFormatter) formatError(err *ErrorInfo) string {
 	return result.String()
 }
 
-// getHelpMessage returns the help message
-func (f *DiscordFormatter) getHelpMessage() string {
-	return `📋 **AI Tech News Bot - Command List**
-
-🔥 **Main Commands:**
-• ` + "`news`" + ` atau ` + "`berita`" + ` - Dapatkan berita teknologi terbaru
-• ` + "`hello`" + ` atau ` + "`hi`" + ` - Sapa bot
-• ` + "`help`" + ` atau ` + "`bantuan`" + ` - Tampilkan menu ini
-• ` + "`ping`" + ` - Cek status koneksi bot
-• ` + "`status`" + ` - Lihat status bot
-
-🔍 **Search Commands**:
-• ` + "`search <keyword>`" + ` - Cari berita berdasarkan kata kunci
-• ` + "`cari <keyword>`" + ` - Pencarian dalam bahasa Indonesia
-
-📝 **Contoh Pencarian:**
-• ` + "`search AI`" + ` - Cari berita tentang AI
-• ` + "`cari blockchain`" + ` - Cari berita blockchain
-• ` + "`search startup`" + ` - Cari berita startup
-
----
-🤖 **About**: Saya adalah bot yang menyediakan berita teknologi terbaru dari berbagai sumber terpercaya.
-📡 **Sources**: Hacker News, TechCrunch, dan lainnya.
-⚡ **Update**: Real-time news feed`
+// FormatHelpResponse formats a HelpResponse as a command list grouped by category
+func (f *DiscordFormatter) FormatHelpResponse(resp *HelpResponse) string {
+	var result strings.Builder
+	result.WriteString("📋 **AI Tech News Bot - Command List**\n")
+
+	category := ""
+	for _, command := range resp.Commands {
+		if command.Category != category {
+			category = command.Category
+			result.WriteString(fmt.Sprintf("\n**%s:**\n", category))
+		}
+
+		usage := command.Usage
+		if usage == "" {
+			usage = "/" + command.Name
+		}
+		result.WriteString(fmt.Sprintf("• `%s` - %s", usage, command.Description))
+		if len(command.Aliases) > 0 {
+			result.WriteString(fmt.Sprintf(" (alias: %s)", strings.Join(command.Aliases, ", ")))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(resp.Examples) > 0 {
+		result.WriteString("\n📝 **Contoh Penggunaan:**\n")
+		for _, example := range resp.Examples {
+			result.WriteString(fmt.Sprintf("• `%s`\n", example))
+		}
+	}
+
+	result.WriteString("\n💡 **Tips**: Gunakan slash command, atau prefix `/` atau `!` untuk command teks\n")
+	result.WriteString("---\n🤖 **About**: Saya adalah bot yang menyediakan berita teknologi terbaru dari berbagai sumber terpercaya.")
+	return result.String()
 }
 
 // min returns the minimum of two integers
@@ -2,7 +2,10 @@ package response
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -145,9 +148,12 @@ func (h *JSONHandler) RequestLogger() gin.HandlerFunc {
 	})
 }
 
-// RateLimitError sends a rate limit exceeded response
-func (h *JSONHandler) RateLimitError(c *gin.Context) {
-	h.Error(c, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "Too many requests, please try again later")
+// RateLimitError sends a rate limit exceeded response with a Retry-After header in seconds
+func (h *JSONHandler) RateLimitError(c *gin.Context, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	h.Error(c, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED", "Too many requests, please try again later",
+		fmt.Sprintf("retry after %d seconds", seconds))
 }
 
 // ValidationError sends a validation error response
@@ -36,6 +36,9 @@ type NewsItem struct {
 	PublishedAt time.Time `json:"published_at"`
 	Source      string    `json:"source"`
 	Score       int       `json:"score,omitempty"`
+	Comments    int       `json:"comments,omitempty"`
+	Related     []string  `json:"related_sources,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
 	Category    string    `json:"category,omitempty"`
 	Tags        []string  `json:"tags,omitempty"`
 	TimeAgo     string    `json:"time_ago,omitempty"`
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/robfig/cron/v3"
+)
+
+// catchUpDelay memberi waktu koneksi gateway Discord siap sebelum catch-up dikirim
+const catchUpDelay = 30 * time.Second
+
+// scheduleCatchUps mencari jadwal yang terlewat selama bot mati (dalam catchUpGrace
+// terakhir) dan menjadwalkan satu kali kiriman susulan per jadwal dan zona waktu.
+// Beberapa run yang terlewat tetap hanya menghasilkan satu kiriman.
+func (cs *CronService) scheduleCatchUps() {
+	if cs.catchUpGrace <= 0 {
+		return
+	}
+
+	schedules, err := cs.schedules.ListSchedules()
+	if err != nil {
+		log.Printf("⚠️ [CATCH-UP] Failed to load schedules: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		if schedule.Paused {
+			continue
+		}
+
+		locations, err := cs.scheduleLocations(schedule)
+		if err != nil {
+			continue
+		}
+
+		for _, location := range locations {
+			missedAt, ok := cs.missedRun(schedule, location, now)
+			if !ok {
+				continue
+			}
+
+			// Dicatat di catchUps agar pause/remove jadwal ikut membatalkannya
+			cs.mu.Lock()
+			job, err := cs.scheduler.NewJob(
+				gocron.OneTimeJob(gocron.OneTimeJobStartDateTime(now.Add(catchUpDelay))),
+				gocron.NewTask(cs.runCatchUp, schedule, location, missedAt),
+				gocron.WithName(schedule.Name+" (catch-up)"),
+				gocron.WithTags(schedule.ID, location.String()),
+			)
+			if err == nil {
+				cs.catchUps[schedule.ID] = append(cs.catchUps[schedule.ID], job)
+			}
+			cs.mu.Unlock()
+			if err != nil {
+				log.Printf("❌ [CATCH-UP] Failed to schedule catch-up for '%s': %v", schedule.Name, err)
+				continue
+			}
+			log.Printf("⏰ [CATCH-UP] Schedule '%s' missed its %s run, posting a delayed update shortly",
+				schedule.Name, missedAt.In(location).Format("15:04 MST"))
+		}
+	}
+}
+
+// missedRun mengembalikan waktu run terakhir yang seharusnya terjadi dalam grace
+// window tetapi belum tercatat sukses
+func (cs *CronService) missedRun(schedule repository.Schedule, location *time.Location, now time.Time) (time.Time, bool) {
+	cronSchedule, err := cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", location, schedule.CronExpr))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// Cari jadwal terakhir sebelum sekarang di dalam grace window
+	var lastDue time.Time
+	for next := cronSchedule.Next(now.Add(-cs.catchUpGrace)); next.Before(now); next = cronSchedule.Next(next) {
+		lastDue = next
+	}
+	if lastDue.IsZero() || schedule.CreatedAt.After(lastDue) {
+		return time.Time{}, false
+	}
+
+	lastSuccess, err := cs.schedules.LastSuccess(schedule.ID, location.String())
+	if err != nil {
+		log.Printf("⚠️ [CATCH-UP] Failed to read last run of '%s': %v", schedule.Name, err)
+		return time.Time{}, false
+	}
+	if !lastSuccess.Before(lastDue) {
+		return time.Time{}, false
+	}
+
+	return lastDue, true
+}
+
+// runCatchUp mengirim jadwal yang terlewat dengan label bahwa kiriman ini tertunda
+func (cs *CronService) runCatchUp(schedule repository.Schedule, location *time.Location, missedAt time.Time) {
+	cs.forgetCatchUp(schedule.ID, location)
+
+	schedule.Header += fmt.Sprintf("\n⏰ *Kiriman tertunda: jadwal %s terlewat saat bot offline*", missedAt.In(location).Format("15:04 MST"))
+	cs.executeSchedule(schedule, location, true)
+}
+
+// forgetCatchUp menghapus catatan catch-up yang sedang berjalan; gocron sendiri
+// membuang one-time job setelah dijalankan
+func (cs *CronService) forgetCatchUp(scheduleID string, location *time.Location) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	var pending []gocron.Job
+	for _, job := range cs.catchUps[scheduleID] {
+		if jobLocation(job, cs.location).String() != location.String() {
+			pending = append(pending, job)
+		}
+	}
+	if len(pending) == 0 {
+		delete(cs.catchUps, scheduleID)
+		return
+	}
+	cs.catchUps[scheduleID] = pending
+}
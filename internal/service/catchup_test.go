@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+// addOldSchedule menyimpan jadwal yang dibuat sebulan lalu, agar run-nya yang
+// sudah lewat bisa dianggap terlewat
+func addOldSchedule(t *testing.T, repo *repository.BoltScheduleRepository, schedule repository.Schedule) repository.Schedule {
+	t.Helper()
+	schedule.CreatedAt = time.Now().AddDate(0, -1, 0)
+	if err := repo.SaveSchedule(&schedule); err != nil {
+		t.Fatalf("SaveSchedule: %v", err)
+	}
+	return schedule
+}
+
+func TestMissedRun(t *testing.T) {
+	cs, repo := newTestCronService(t, CronOptions{CatchUpGrace: 6 * time.Hour})
+	location := cs.location
+	today := time.Now().In(location)
+	now := time.Date(today.Year(), today.Month(), today.Day(), 10, 0, 0, 0, location)
+	schedule := addOldSchedule(t, repo, repository.Schedule{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"})
+
+	missedAt, ok := cs.missedRun(schedule, location, now)
+	if want := now.Add(-2 * time.Hour); !ok || !missedAt.Equal(want) {
+		t.Fatalf("missedRun = %v, %t; want %v", missedAt, ok, want)
+	}
+
+	if err := repo.RecordSuccess("morning", location.String(), now.Add(-2*time.Hour+5*time.Second)); err != nil {
+		t.Fatalf("RecordSuccess: %v", err)
+	}
+	if _, ok := cs.missedRun(schedule, location, now); ok {
+		t.Error("run that succeeded should not be caught up")
+	}
+
+	// Di luar grace window
+	late := now.Add(5 * time.Hour)
+	early := addOldSchedule(t, repo, repository.Schedule{ID: "early", Name: "early", CronExpr: "0 6 * * *"})
+	if _, ok := cs.missedRun(early, location, late); ok {
+		t.Error("run older than the grace window should not be caught up")
+	}
+}
+
+func TestCatchUpCancelledWithSchedule(t *testing.T) {
+	cs, repo := newTestCronService(t, CronOptions{CatchUpGrace: 24 * time.Hour})
+	addOldSchedule(t, repo, repository.Schedule{ID: "hourly", Name: "hourly", CronExpr: "0 * * * *"})
+	addOldSchedule(t, repo, repository.Schedule{ID: "other", Name: "other", CronExpr: "30 * * * *"})
+
+	if err := cs.Start(newFakeBot("guild-1")); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	cs.mu.Lock()
+	pending := len(cs.catchUps["hourly"])
+	cs.mu.Unlock()
+	if pending != 1 {
+		t.Fatalf("pending catch-ups = %d, want 1", pending)
+	}
+
+	// Reload (misal guild join) tidak membatalkan catch-up
+	if err := cs.ReloadSchedules(); err != nil {
+		t.Fatalf("ReloadSchedules: %v", err)
+	}
+	if n := countJobs(cs, "hourly (catch-up)"); n != 1 {
+		t.Fatalf("catch-up jobs after reload = %d, want 1", n)
+	}
+
+	if _, err := cs.PauseSchedule("hourly"); err != nil {
+		t.Fatalf("PauseSchedule: %v", err)
+	}
+	if n := countJobs(cs, "hourly (catch-up)"); n != 0 {
+		t.Errorf("catch-up jobs after pause = %d, want 0", n)
+	}
+
+	if _, err := cs.RemoveSchedule("other"); err != nil {
+		t.Fatalf("RemoveSchedule: %v", err)
+	}
+	if n := countJobs(cs, "other (catch-up)"); n != 0 {
+		t.Errorf("catch-up jobs after remove = %d, want 0", n)
+	}
+}
+
+func countJobs(cs *CronService, name string) int {
+	count := 0
+	for _, job := range cs.scheduler.Jobs() {
+		if job.Name() == name {
+			count++
+		}
+	}
+	return count
+}
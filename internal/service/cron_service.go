@@ -2,255 +2,1097 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
-	"net/http"
-	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+
 	"github.com/go-co-op/gocron/v2"
+	"github.com/robfig/cron/v3"
 )
 
 type CronService struct {
-	scheduler   gocron.Scheduler
-	newsService NewsService
-	discordBot  DiscordBotInterface // Tambahkan interface untuk Discord bot
+	scheduler    gocron.Scheduler
+	newsService  NewsService
+	discordBot   DiscordBotInterface // Tambahkan interface untuk Discord bot
+	history      repository.HistoryRepository
+	guildConfig  *GuildConfigService
+	schedules    repository.ScheduleRepository
+	repostWindow time.Duration
+	location     *time.Location // zona waktu default jadwal
+	catchUpGrace time.Duration  // jadwal yang terlewat dalam window ini dikirim susulan saat startup
+	minInterval  time.Duration  // jarak minimal antar run untuk jadwal baru
+	renderer     *response.EmbedRenderer
+	runs         *RunHistory
+	elector      gocron.Elector
+	digest       *DigestService
+	retention    time.Duration
+
+	mu       sync.Mutex
+	jobs     map[string][]gocron.Job // schedule ID -> job gocron yang aktif, satu per zona waktu
+	catchUps map[string][]gocron.Job // schedule ID -> catch-up yang belum berjalan; terpisah agar tidak ikut diganti ReloadSchedules
+	running  atomic.Bool
+	loaded   atomic.Bool // true setelah Start mulai mendaftarkan jadwal
+
+	// addMu membuat cek nama unik dan penyimpanan di AddSchedule atomik
+	addMu sync.Mutex
+}
+
+// JobStatus adalah ringkasan satu job gocron untuk endpoint health dan command /cron
+type JobStatus struct {
+	JobID      string
+	ScheduleID string
+	Name       string
+	CronExpr   string
+	Location   *time.Location
+	NextRun    time.Time
+	LastRun    time.Time
+	LastResult *RunRecord // nil jika job belum pernah berjalan sejak startup
+}
+
+// Status scheduler untuk /cron dan /health/cron
+const (
+	SchedulerRunning = "running"
+	SchedulerStopped = "stopped"
+	// SchedulerNotLeader: scheduler berjalan, tetapi job dijalankan replica lain
+	SchedulerNotLeader = "not leader"
+)
+
+// SchedulerStatus adalah status scheduler yang dibaca langsung dari memori proses.
+// Dipakai bersama oleh command /cron dan endpoint /health/cron.
+type SchedulerStatus interface {
+	// State mengembalikan SchedulerRunning, SchedulerStopped atau SchedulerNotLeader
+	State() string
+	JobStatuses() []JobStatus
+	RunHistory(scheduleID string, limit int) []RunRecord
+	DefaultLocation() *time.Location
+}
+
+// ScheduleManager menambahkan operasi admin jadwal di atas SchedulerStatus
+type ScheduleManager interface {
+	SchedulerStatus
+	ListSchedules() ([]repository.Schedule, error)
+	GetSchedule(id string) (*repository.Schedule, error)
+	AddSchedule(schedule repository.Schedule) (*repository.Schedule, error)
+	RemoveSchedule(id string) (*repository.Schedule, error)
+	PauseSchedule(id string) (*repository.Schedule, error)
+	ResumeSchedule(id string) (*repository.Schedule, error)
+	ReloadSchedules() error
+	// RequireLeader mengembalikan error (repository.ErrNotLeader) jika replica ini bukan
+	// leader. State tidak dibagi antar replica, jadi perubahan yang dipakai job
+	// terjadwal hanya diterima oleh leader.
+	RequireLeader() error
+	// GuildJoined dipanggil saat bot bergabung (atau tersambung kembali) ke guild
+	GuildJoined(guildID string)
 }
 
+var _ ScheduleManager = (*CronService)(nil)
+
+// errNoTargetChannel dicatat sebagai hasil run jika tidak ada channel tujuan
+var errNoTargetChannel = errors.New("no target channel")
+
 // Interface untuk Discord bot
 type DiscordBotInterface interface {
-	SendNewsToChannel(channelName string, message string) error
+	GuildIDs() []string
+	FindChannelID(guildID string, channelName string) (string, error)
+	// ChannelGuildID mengembalikan ID guild pemilik channel
+	ChannelGuildID(channelID string) (string, error)
+	// SendMessage mengembalikan ID pesan yang terkirim
+	SendMessage(channelID string, message *response.DiscordMessage) (string, error)
+}
+
+// ErrInvalidSchedule dikembalikan jika data jadwal baru tidak valid (misal cron expression salah)
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// DefaultSchedules disimpan ke database saat pertama kali dijalankan.
+// Jam ditulis dalam zona waktu target (default Asia/Jakarta), bukan waktu server.
+// Digest disimpan dalam keadaan pause (opt-in); aktifkan lewat resume di admin API.
+var DefaultSchedules = []repository.Schedule{
+	{ID: "morning", Name: "morning", CronExpr: "0 8 * * *", Header: "🌅 **Good Morning! Tech News Update**"},
+	{ID: "afternoon", Name: "afternoon", CronExpr: "0 13 * * *", Header: "🌞 **Afternoon Tech News Update**"},
+	{ID: "evening", Name: "evening", CronExpr: "0 17 * * *", Header: "🌆 **Evening Tech News Update**"},
+	{ID: "weekly-digest", Name: "weekly-digest", CronExpr: "0 16 * * 5", Kind: repository.ScheduleKindWeeklyDigest, Header: "🗞️ **Week in Tech - Top Stories Minggu Ini**", Paused: true},
+	{ID: "monthly-digest", Name: "monthly-digest", CronExpr: "0 9 1 * *", Kind: repository.ScheduleKindMonthlyDigest, Header: "📆 **Month in Tech - Top Stories Bulan Lalu**", Paused: true},
+}
+
+// legacyDefaultCron adalah cron expression lama yang di-shift manual ke waktu
+// server Frankfurt; diganti dengan DefaultSchedules saat startup
+var legacyDefaultCron = map[string]string{
+	"morning":   "0 1 * * *",
+	"afternoon": "0 6 * * *",
+	"evening":   "0 11 * * *",
+}
+
+// CronOptions mengatur perilaku CronService
+type CronOptions struct {
+	// RepostWindow: artikel yang sudah dikirim ke channel yang sama dalam window ini tidak dikirim ulang
+	RepostWindow time.Duration
+	// Location: zona waktu untuk jadwal yang tidak menentukan zonanya sendiri
+	Location *time.Location
+	// CatchUpGrace: jadwal yang terlewat selama bot mati dalam window ini dikirim susulan
+	// sekali saat startup (0 untuk menonaktifkan)
+	CatchUpGrace time.Duration
+	// MinInterval: jarak minimal antar dua run berurutan untuk jadwal yang ditambahkan
+	// lewat AddSchedule (0 untuk tanpa batas)
+	MinInterval time.Duration
+	// Elector dipakai saat beberapa replica berjalan bersamaan: hanya instance yang
+	// menjadi leader yang menjalankan job. nil berarti instance tunggal.
+	Elector gocron.Elector
+	// Retention: riwayat artikel yang tidak aktif lebih lama dari ini dihapus setiap hari
+	// (harus lebih panjang dari periode digest bulanan)
+	Retention time.Duration
 }
 
-func NewCronService(newsService NewsService, discordBot DiscordBotInterface) *CronService {
-	scheduler, err := gocron.NewScheduler()
+func NewCronService(newsService NewsService, history repository.HistoryRepository, guildConfig *GuildConfigService, schedules repository.ScheduleRepository, options CronOptions) *CronService {
+	var schedulerOptions []gocron.SchedulerOption
+	if options.Elector != nil {
+		schedulerOptions = append(schedulerOptions, gocron.WithDistributedElector(options.Elector))
+	}
+
+	scheduler, err := gocron.NewScheduler(schedulerOptions...)
 	if err != nil {
 		log.Fatalf("Failed to create scheduler: %v", err)
 	}
 
-	return &CronService{
-		scheduler:   scheduler,
-		newsService: newsService,
-		discordBot:  discordBot,
+	cs := &CronService{
+		scheduler:    scheduler,
+		newsService:  newsService,
+		history:      history,
+		guildConfig:  guildConfig,
+		schedules:    schedules,
+		repostWindow: options.RepostWindow,
+		location:     options.Location,
+		catchUpGrace: options.CatchUpGrace,
+		minInterval:  options.MinInterval,
+		renderer:     response.NewEmbedRenderer(),
+		runs:         NewRunHistory(maxRunHistory),
+		elector:      options.Elector,
+		digest:       NewDigestService(history),
+		retention:    options.Retention,
+		jobs:         make(map[string][]gocron.Job),
+		catchUps:     make(map[string][]gocron.Job),
 	}
+	guildConfig.OnChange(cs.guildConfigChanged)
+	return cs
 }
 
-func (cs *CronService) Start() error {
-	// Frankfurt is GMT+1 (GMT+2 during daylight saving)
-	// To achieve GMT+7 timing, we need to subtract 6 hours (or 5 during daylight saving)
-	// For safety, let's use 6 hours offset consistently
+// Start memuat semua jadwal dari database lalu menjalankan scheduler.
+// discordBot diberikan di sini (bukan di constructor) karena bot baru bisa
+// dibuat setelah usecase, yang juga memakai CronService untuk admin command.
+func (cs *CronService) Start(discordBot DiscordBotInterface) error {
+	cs.discordBot = discordBot
+
+	if err := cs.migrateLegacyDefaults(); err != nil {
+		log.Printf("⚠️ Failed to migrate default schedules: %v", err)
+	}
+
+	cs.loaded.Store(true)
+	if err := cs.ReloadSchedules(); err != nil {
+		return err
+	}
+
+	// Bersihkan riwayat artikel lama setiap hari
+	if cs.retention > 0 {
+		_, err := cs.scheduler.NewJob(
+			gocron.CronJob(fmt.Sprintf("CRON_TZ=%s 0 3 * * *", cs.location), false),
+			gocron.NewTask(cs.pruneHistory),
+			gocron.WithName("history-prune"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	cs.scheduleCatchUps()
+
+	cs.scheduler.Start()
+	cs.running.Store(true)
+	log.Println("✅ Cron service started successfully")
+	if cs.retention > 0 {
+		log.Printf("🧹 Article history retention: %s", cs.retention)
+	}
+	log.Printf("🌍 Default timezone: %s (server: %s)", cs.location, time.Local)
+	return nil
+}
 
-	// Auto news pada jam 08:00 WIB = 02:00 Frankfurt time
+// AddIntervalJob mendaftarkan job berkala di luar jadwal berita, misalnya polling
+// watchlist. Seperti job lain, hanya dijalankan oleh leader jika election aktif.
+func (cs *CronService) AddIntervalJob(name string, interval time.Duration, task func()) error {
 	_, err := cs.scheduler.NewJob(
-		gocron.CronJob("0 1 * * *", false), // 02:00 Frankfurt = 08:00 WIB
-		gocron.NewTask(cs.sendMorningNews),
+		gocron.DurationJob(interval),
+		gocron.NewTask(task),
+		gocron.WithName(name),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
 	)
 	if err != nil {
 		return err
 	}
 
-	// Auto news pada jam 13:00 WIB = 07:00 Frankfurt time
-	_, err = cs.scheduler.NewJob(
-		gocron.CronJob("0 6 * * *", false),
-		gocron.NewTask(cs.sendAfternoonNews),
-	)
+	log.Printf("🔁 Job '%s' registered: every %s", name, interval)
+	return nil
+}
+
+// ReloadSchedules mendaftarkan ulang semua jadwal aktif, misalnya setelah
+// zona waktu guild berubah
+func (cs *CronService) ReloadSchedules() error {
+	schedules, err := cs.schedules.ListSchedules()
 	if err != nil {
 		return err
 	}
 
-	// Auto news pada jam 17:00 WIB = 11:00 Frankfurt time
-	_, err = cs.scheduler.NewJob(
-		gocron.CronJob("0 11 * * *", false), // 11:00 Frankfurt = 17:00 WIB
-		gocron.NewTask(cs.sendEveningNews),
-	)
+	for _, schedule := range schedules {
+		if schedule.Paused {
+			log.Printf("⏸️ Schedule '%s' (%s) is paused", schedule.Name, schedule.ID)
+			continue
+		}
+		if err := cs.registerJob(schedule); err != nil {
+			log.Printf("❌ ERROR: Failed to register schedule '%s' (%s): %v", schedule.Name, schedule.ID, err)
+			continue
+		}
+		log.Printf("📅 Schedule '%s' registered: %s", schedule.Name, schedule.CronExpr)
+	}
+	return nil
+}
+
+// GuildJoined mendaftarkan ulang jadwal jika zona waktu guild yang baru bergabung
+// belum punya job, agar jadwal global langsung berjalan di zona override guild itu
+// tanpa menunggu restart. Discord juga mengirim event ini untuk setiap guild saat
+// tersambung, jadi zona yang sudah terdaftar tidak memicu reload.
+func (cs *CronService) GuildJoined(guildID string) {
+	if !cs.loaded.Load() {
+		return // Start akan mendaftarkan semua zona waktu guild
+	}
+
+	location := cs.guildLocation(guildID)
+	if cs.hasLocation(location) {
+		return
+	}
+
+	log.Printf("🌍 Guild %s uses timezone %s, reloading schedules", guildID, location)
+	if err := cs.ReloadSchedules(); err != nil {
+		log.Printf("⚠️ Failed to reload schedules for guild %s: %v", guildID, err)
+	}
+}
+
+// guildConfigChanged mendaftarkan ulang jadwal saat zona waktu guild berubah,
+// dari command Discord maupun jalur lain yang memakai GuildConfigService
+func (cs *CronService) guildConfigChanged(previous, current repository.GuildConfig) {
+	if previous.Timezone == current.Timezone || !cs.loaded.Load() {
+		return
+	}
+
+	log.Printf("🌍 Guild %s timezone changed to %q, reloading schedules", current.GuildID, current.Timezone)
+	if err := cs.ReloadSchedules(); err != nil {
+		log.Printf("⚠️ Failed to reload schedules after timezone change: %v", err)
+	}
+}
+
+// hasLocation melaporkan apakah sudah ada job jadwal global di zona waktu location
+func (cs *CronService) hasLocation(location *time.Location) bool {
+	schedules, err := cs.schedules.ListSchedules()
 	if err != nil {
-		return err
+		return false
 	}
 
-	// Service health check - every minute
-	_, err = cs.scheduler.NewJob(
-		gocron.CronJob("* * * * *", false), // Setiap menit
-		gocron.NewTask(cs.pingStartEndpoint),
-	)
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	global := false
+	for _, schedule := range schedules {
+		if schedule.Paused || schedule.GuildID != "" || schedule.Timezone != "" {
+			continue
+		}
+		global = true
+		for _, job := range cs.jobs[schedule.ID] {
+			if jobLocation(job, cs.location).String() == location.String() {
+				return true
+			}
+		}
+	}
+	// Tanpa jadwal global aktif tidak ada yang perlu didaftarkan di zona baru
+	return !global
+}
+
+// migrateLegacyDefaults mengganti jadwal default lama yang di-shift manual ke
+// waktu Frankfurt dengan versi yang memakai zona waktu target
+func (cs *CronService) migrateLegacyDefaults() error {
+	for _, defaultSchedule := range DefaultSchedules {
+		schedule, err := cs.schedules.GetSchedule(defaultSchedule.ID)
+		if errors.Is(err, repository.ErrScheduleNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if schedule.Timezone != "" || schedule.CronExpr != legacyDefaultCron[schedule.ID] {
+			continue
+		}
+
+		schedule.CronExpr = defaultSchedule.CronExpr
+		if err := cs.schedules.SaveSchedule(schedule); err != nil {
+			return err
+		}
+		log.Printf("🔁 Schedule '%s' migrated from Frankfurt offset to %s", schedule.Name, schedule.CronExpr)
+	}
+	return nil
+}
+
+// JobStatuses mengembalikan job gocron yang aktif beserta jadwal berikutnya
+func (cs *CronService) JobStatuses() []JobStatus {
+	schedules, err := cs.schedules.ListSchedules()
+	if err != nil {
+		log.Printf("⚠️ Failed to load schedules: %v", err)
+		return nil
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	var statuses []JobStatus
+	for _, schedule := range schedules {
+		for _, job := range cs.jobs[schedule.ID] {
+			status := JobStatus{
+				JobID:      job.ID().String(),
+				ScheduleID: schedule.ID,
+				Name:       schedule.Name,
+				CronExpr:   schedule.CronExpr,
+				Location:   jobLocation(job, cs.location),
+			}
+			if nextRun, err := job.NextRun(); err == nil {
+				status.NextRun = nextRun
+			}
+			if lastRun, err := job.LastRun(); err == nil {
+				status.LastRun = lastRun
+			}
+			status.LastResult = cs.runs.Last(schedule.ID, status.Location.String())
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// RunHistory mengembalikan riwayat run terbaru lebih dulu; scheduleID kosong berarti semua jadwal
+func (cs *CronService) RunHistory(scheduleID string, limit int) []RunRecord {
+	return cs.runs.List(scheduleID, limit)
+}
+
+// Running melaporkan apakah scheduler sudah dijalankan dan belum dihentikan.
+// Replica yang bukan leader tetap dianggap running; job-nya hanya dilewati.
+func (cs *CronService) Running() bool {
+	return cs.running.Load()
+}
+
+// State membaca status scheduler: stopped sebelum Start atau setelah Stop, dan not
+// leader jika replica ini tidak memegang leader lease (gocron juga melewati job
+// jika pemeriksaan lease gagal)
+func (cs *CronService) State() string {
+	if !cs.Running() {
+		return SchedulerStopped
+	}
+	if err := cs.RequireLeader(); err != nil {
+		return SchedulerNotLeader
+	}
+	return SchedulerRunning
+}
+
+// DefaultLocation mengembalikan zona waktu default jadwal
+func (cs *CronService) DefaultLocation() *time.Location {
+	return cs.location
+}
+
+func (cs *CronService) Stop() error {
+	log.Println("🛑 Stopping cron service...")
+	cs.running.Store(false)
+	err := cs.scheduler.Shutdown()
+
+	// Lepas leadership agar replica lain bisa langsung mengambil alih
+	if releaser, ok := cs.elector.(interface{ Release() error }); ok {
+		if releaseErr := releaser.Release(); releaseErr != nil {
+			log.Printf("⚠️ Failed to release leader lease: %v", releaseErr)
+		}
+	}
+	return err
+}
+
+// leaderCheckTimeout membatasi waktu menunggu lock lease saat memeriksa leadership
+const leaderCheckTimeout = 5 * time.Second
+
+// RequireLeader memeriksa bahwa replica ini memegang leader lease. Tanpa election
+// (instance tunggal) selalu nil.
+func (cs *CronService) RequireLeader() error {
+	if cs.elector == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), leaderCheckTimeout)
+	defer cancel()
+	return cs.elector.IsLeader(ctx)
+}
+
+// ListSchedules mengembalikan semua jadwal yang tersimpan, termasuk yang di-pause
+func (cs *CronService) ListSchedules() ([]repository.Schedule, error) {
+	return cs.schedules.ListSchedules()
+}
+
+// GetSchedule mengembalikan satu jadwal berdasarkan ID
+func (cs *CronService) GetSchedule(id string) (*repository.Schedule, error) {
+	return cs.schedules.GetSchedule(id)
+}
+
+// AddSchedule memvalidasi, menyimpan dan langsung mengaktifkan jadwal baru.
+// Nama jadwal harus unik di antara jadwal global dan jadwal guild yang sama, karena
+// /config schedules memilih jadwal berdasarkan nama.
+func (cs *CronService) AddSchedule(schedule repository.Schedule) (*repository.Schedule, error) {
+	if err := cs.RequireLeader(); err != nil {
+		return nil, err
+	}
+
+	schedule.Name = strings.ToLower(strings.TrimSpace(schedule.Name))
+	schedule.CronExpr = strings.TrimSpace(schedule.CronExpr)
+	schedule.Query = strings.TrimSpace(schedule.Query)
+	schedule.Timezone = strings.TrimSpace(schedule.Timezone)
+	schedule.Kind = strings.TrimSpace(schedule.Kind)
+
+	if schedule.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidSchedule)
+	}
+	if schedule.CronExpr == "" {
+		return nil, fmt.Errorf("%w: cron expression is required", ErrInvalidSchedule)
+	}
+	if strings.HasPrefix(schedule.CronExpr, "TZ=") || strings.HasPrefix(schedule.CronExpr, "CRON_TZ=") {
+		return nil, fmt.Errorf("%w: set the timezone field instead of a TZ= prefix", ErrInvalidSchedule)
+	}
+	location := cs.location
+	if schedule.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(schedule.Timezone); err != nil {
+			return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, schedule.Timezone)
+		}
+	}
+	if err := checkMinInterval(schedule.CronExpr, location, cs.minInterval); err != nil {
+		return nil, err
+	}
+	switch schedule.Kind {
+	case repository.ScheduleKindNews, repository.ScheduleKindWeeklyDigest, repository.ScheduleKindMonthlyDigest:
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q (expected %s or %s)", ErrInvalidSchedule, schedule.Kind,
+			repository.ScheduleKindWeeklyDigest, repository.ScheduleKindMonthlyDigest)
+	}
+	if schedule.Kind != repository.ScheduleKindNews && schedule.Query != "" {
+		return nil, fmt.Errorf("%w: digest schedules do not support a query", ErrInvalidSchedule)
+	}
+	if err := cs.checkChannel(schedule); err != nil {
+		return nil, err
+	}
+	if schedule.Header == "" {
+		schedule.Header = fmt.Sprintf("📰 **%s - Tech News Update**", schedule.Name)
+		if schedule.Kind != repository.ScheduleKindNews {
+			schedule.Header = fmt.Sprintf("🗞️ **%s - Top Tech Stories**", schedule.Name)
+		}
+	}
+
+	cs.addMu.Lock()
+	defer cs.addMu.Unlock()
+
+	if err := cs.checkUniqueName(schedule); err != nil {
+		return nil, err
+	}
+
+	id, err := newScheduleID()
+	if err != nil {
+		return nil, err
+	}
+	schedule.ID = id
+	schedule.Paused = false
+	schedule.CreatedAt = time.Now()
+
+	// Daftarkan ke gocron dulu agar cron expression yang salah ditolak sebelum disimpan
+	if err := cs.registerJob(schedule); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+
+	if err := cs.schedules.SaveSchedule(&schedule); err != nil {
+		cs.unregisterJob(schedule.ID)
+		return nil, err
+	}
+
+	log.Printf("➕ Schedule '%s' (%s) added: %s", schedule.Name, schedule.ID, schedule.CronExpr)
+	return &schedule, nil
+}
+
+// checkChannel memastikan channel tujuan milik guild jadwal. Jadwal dengan channel
+// harus punya guild, karena jadwal global didaftarkan di zona waktu setiap guild dan
+// typo channel ID baru ketahuan saat pengiriman gagal.
+func (cs *CronService) checkChannel(schedule repository.Schedule) error {
+	if schedule.ChannelID == "" {
+		return nil
+	}
+	if schedule.GuildID == "" {
+		return fmt.Errorf("%w: guild_id is required when channel_id is set", ErrInvalidSchedule)
+	}
+	if cs.discordBot == nil {
+		return nil
+	}
+
+	guildID, err := cs.discordBot.ChannelGuildID(schedule.ChannelID)
+	if err != nil {
+		return fmt.Errorf("%w: unknown channel %s", ErrInvalidSchedule, schedule.ChannelID)
+	}
+	if guildID != schedule.GuildID {
+		return fmt.Errorf("%w: channel %s does not belong to guild %s", ErrInvalidSchedule, schedule.ChannelID, schedule.GuildID)
+	}
+	return nil
+}
+
+// checkUniqueName menolak nama yang sudah dipakai jadwal global, jadwal guild yang
+// sama, atau (untuk jadwal global baru) jadwal guild mana pun
+func (cs *CronService) checkUniqueName(schedule repository.Schedule) error {
+	existing, err := cs.schedules.ListSchedules()
 	if err != nil {
 		return err
 	}
 
-	_, err = cs.scheduler.NewJob(
-		gocron.CronJob("00 18 * * *", false),
-		gocron.NewTask(cs.sendTestNews),
-	)
+	for _, other := range existing {
+		if other.Name != schedule.Name {
+			continue
+		}
+		if other.GuildID == "" || schedule.GuildID == "" || other.GuildID == schedule.GuildID {
+			return fmt.Errorf("%w: a schedule named %q already exists (id %s)", ErrInvalidSchedule, schedule.Name, other.ID)
+		}
+	}
+	return nil
+}
+
+// maxIntervalSamples membatasi jumlah run yang diperiksa checkMinInterval
+const maxIntervalSamples = 10000
+
+// checkMinInterval menolak cron expression yang dua run berurutannya berjarak kurang
+// dari minInterval. Run diperiksa selama setahun ke depan agar pola per jam, hari,
+// minggu dan bulan (misal "*/5 9 * * 1") ikut tertangkap.
+func checkMinInterval(cronExpr string, location *time.Location, minInterval time.Duration) error {
+	if minInterval <= 0 {
+		return nil
+	}
+
+	cronSchedule, err := cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", location, cronExpr))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+
+	previous := cronSchedule.Next(time.Now())
+	end := previous.AddDate(1, 0, 0)
+	for i := 0; i < maxIntervalSamples && !previous.IsZero() && previous.Before(end); i++ {
+		next := cronSchedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(previous); gap < minInterval {
+			return fmt.Errorf("%w: runs %s apart, the minimum is %s", ErrInvalidSchedule, gap, minInterval)
+		}
+		previous = next
+	}
+	return nil
+}
+
+// RemoveSchedule menghentikan dan menghapus jadwal secara permanen
+func (cs *CronService) RemoveSchedule(id string) (*repository.Schedule, error) {
+	if err := cs.RequireLeader(); err != nil {
+		return nil, err
+	}
+
+	schedule, err := cs.schedules.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cs.schedules.DeleteSchedule(id); err != nil {
+		return nil, err
+	}
+	cs.unregisterJob(id)
+
+	log.Printf("➖ Schedule '%s' (%s) removed", schedule.Name, schedule.ID)
+	return schedule, nil
+}
+
+// PauseSchedule menghentikan jadwal tanpa menghapusnya
+func (cs *CronService) PauseSchedule(id string) (*repository.Schedule, error) {
+	return cs.setPaused(id, true)
+}
+
+// ResumeSchedule mengaktifkan kembali jadwal yang di-pause
+func (cs *CronService) ResumeSchedule(id string) (*repository.Schedule, error) {
+	return cs.setPaused(id, false)
+}
+
+func (cs *CronService) setPaused(id string, paused bool) (*repository.Schedule, error) {
+	if err := cs.RequireLeader(); err != nil {
+		return nil, err
+	}
+
+	schedule, err := cs.schedules.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Job didaftarkan sebelum disimpan agar jadwal yang tidak valid tidak tersimpan
+	// aktif, lalu dibatalkan jika penyimpanan gagal. Job jadwal yang di-pause baru
+	// dihapus setelah tersimpan, jadi scheduler dan database selalu sama.
+	if !paused {
+		if err := cs.registerJob(*schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule.Paused = paused
+	if err := cs.schedules.SaveSchedule(schedule); err != nil {
+		if !paused {
+			cs.unregisterJob(id)
+		}
+		return nil, err
+	}
+
+	if paused {
+		cs.unregisterJob(id)
+		log.Printf("⏸️ Schedule '%s' (%s) paused", schedule.Name, schedule.ID)
+	} else {
+		log.Printf("▶️ Schedule '%s' (%s) resumed", schedule.Name, schedule.ID)
+	}
+	return schedule, nil
+}
+
+// registerJob membuat (atau mengganti) job gocron untuk sebuah jadwal, satu job
+// per zona waktu yang dilayani jadwal tersebut
+func (cs *CronService) registerJob(schedule repository.Schedule) error {
+	locations, err := cs.scheduleLocations(schedule)
 	if err != nil {
 		return err
 	}
 
-	// Immediate test job - every minute for debugging
-	// _, err = cs.scheduler.NewJob(
-	// 	gocron.CronJob("* * * * *", false), // Every minute
-	// 	gocron.NewTask(cs.sendImmediateTestNews),
-	// )
-	// if err != nil {
-	// 	return err
-	// }
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
 
-	cs.scheduler.Start()
-	log.Println("✅ Cron service started successfully")
-	log.Println("📅 Auto news scheduled at: 08:00, 13:00, 17:00 WIB")
-	log.Println("🧪 Test news scheduled at: 01:00 WIB")
-	log.Println("🔄 Service health check: every minute")
-	log.Println("🌍 Timezone: Adjusted for Frankfurt deployment to match GMT+7 (WIB)")
+	var jobs []gocron.Job
+	for _, location := range locations {
+		job, err := cs.scheduler.NewJob(
+			gocron.CronJob(fmt.Sprintf("CRON_TZ=%s %s", location, schedule.CronExpr), false),
+			gocron.NewTask(cs.runSchedule, schedule, location),
+			gocron.WithName(schedule.Name),
+			gocron.WithTags(schedule.ID, location.String()),
+		)
+		if err != nil {
+			cs.removeJobs(jobs)
+			return err
+		}
+		jobs = append(jobs, job)
+	}
+
+	cs.removeJobs(cs.jobs[schedule.ID])
+	cs.jobs[schedule.ID] = jobs
 	return nil
 }
 
-func (cs *CronService) Stop() error {
-	log.Println("🛑 Stopping cron service...")
-	return cs.scheduler.Shutdown()
+// unregisterJob menghapus job jadwal beserta catch-up yang belum berjalan
+func (cs *CronService) unregisterJob(id string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.removeJobs(cs.jobs[id])
+	delete(cs.jobs, id)
+	cs.removeJobs(cs.catchUps[id])
+	delete(cs.catchUps, id)
+}
+
+// removeJobs menghapus job dari scheduler; dipanggil dengan mu terkunci
+func (cs *CronService) removeJobs(jobs []gocron.Job) {
+	for _, job := range jobs {
+		if err := cs.scheduler.RemoveJob(job.ID()); err != nil {
+			log.Printf("⚠️ Failed to remove job %s: %v", job.Name(), err)
+		}
+	}
 }
 
-// Helper function to get current time in WIB (GMT+7)
-func (cs *CronService) getWIBTime() time.Time {
-	// Load WIB timezone (GMT+7)
-	wibLocation, err := time.LoadLocation("Asia/Jakarta")
+// scheduleLocations menentukan zona waktu tempat jadwal dijalankan. Jadwal dengan
+// Timezone sendiri, milik satu guild atau terikat satu channel hanya punya satu zona;
+// jadwal global dijalankan sekali di zona default dan sekali di setiap zona override guild.
+func (cs *CronService) scheduleLocations(schedule repository.Schedule) ([]*time.Location, error) {
+	if schedule.Timezone != "" {
+		location, err := time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		return []*time.Location{location}, nil
+	}
+
+	if schedule.GuildID != "" {
+		return []*time.Location{cs.guildLocation(schedule.GuildID)}, nil
+	}
+
+	// Jadwal lama dengan ChannelID tanpa GuildID: setiap zona akan mengirim ke channel
+	// yang sama, jadi jadwal hanya didaftarkan di zona guild pemilik channel
+	if schedule.ChannelID != "" {
+		return []*time.Location{cs.channelLocation(schedule.ChannelID)}, nil
+	}
+
+	locations := []*time.Location{cs.location}
+	seen := map[string]bool{cs.location.String(): true}
+	if cs.discordBot != nil {
+		for _, guildID := range cs.discordBot.GuildIDs() {
+			location := cs.guildLocation(guildID)
+			if !seen[location.String()] {
+				seen[location.String()] = true
+				locations = append(locations, location)
+			}
+		}
+	}
+	return locations, nil
+}
+
+// guildLocation mengembalikan zona waktu override guild, atau zona default
+func (cs *CronService) guildLocation(guildID string) *time.Location {
+	config, err := cs.guildConfig.Get(guildID)
+	if err != nil || config.Timezone == "" {
+		return cs.location
+	}
+
+	location, err := time.LoadLocation(config.Timezone)
 	if err != nil {
-		// Fallback: manually create GMT+7 offset
-		wibLocation = time.FixedZone("WIB", 7*60*60) // 7 hours * 60 minutes * 60 seconds
+		log.Printf("⚠️ Invalid timezone %q for guild %s, using %s", config.Timezone, guildID, cs.location)
+		return cs.location
 	}
-	return time.Now().In(wibLocation)
+	return location
 }
 
-// Job untuk mengirim berita pagi (08:00 WIB)
-func (cs *CronService) sendMorningNews() {
-	wibTime := cs.getWIBTime()
-	log.Printf("🌅 [AUTO NEWS] Sending morning tech news... (WIB: %s)", wibTime.Format("15:04"))
-	cs.sendAutoNews("🌅 **Good Morning! Tech News Update**")
+// channelLocation mengembalikan zona waktu guild pemilik channel, atau zona default
+// jika guild-nya tidak bisa ditemukan
+func (cs *CronService) channelLocation(channelID string) *time.Location {
+	if cs.discordBot == nil {
+		return cs.location
+	}
+
+	guildID, err := cs.discordBot.ChannelGuildID(channelID)
+	if err != nil {
+		log.Printf("⚠️ Guild of channel %s is unknown, using %s: %v", channelID, cs.location, err)
+		return cs.location
+	}
+	return cs.guildLocation(guildID)
 }
 
-// Job untuk mengirim berita siang (13:00 WIB)
-func (cs *CronService) sendAfternoonNews() {
-	wibTime := cs.getWIBTime()
-	log.Printf("🌞 [AUTO NEWS] Sending afternoon tech news... (WIB: %s)", wibTime.Format("15:04"))
-	cs.sendAutoNews("🌞 **Afternoon Tech News Update**")
+// jobLocation membaca zona waktu dari tag job (lihat registerJob)
+func jobLocation(job gocron.Job, fallback *time.Location) *time.Location {
+	tags := job.Tags()
+	if len(tags) < 2 {
+		return fallback
+	}
+	location, err := time.LoadLocation(tags[1])
+	if err != nil {
+		return fallback
+	}
+	return location
 }
 
-// Job untuk mengirim berita sore (17:00 WIB)
-func (cs *CronService) sendEveningNews() {
-	wibTime := cs.getWIBTime()
-	log.Printf("🌆 [AUTO NEWS] Sending evening tech news... (WIB: %s)", wibTime.Format("15:04"))
-	cs.sendAutoNews("🌆 **Evening Tech News Update**")
+// newScheduleID membuat ID pendek acak untuk jadwal buatan admin
+func newScheduleID() (string, error) {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
 }
 
-// Job untuk mengirim berita test (00:40 WIB)
-func (cs *CronService) sendTestNews() {
-	wibTime := cs.getWIBTime()
-	log.Printf("🧪 [TEST NEWS] Sending test tech news... (WIB: %s)", wibTime.Format("15:04"))
-	params := fmt.Sprintf("🧪 **Test News Update - %s WIB**", wibTime.Format("15:04"))
-	cs.sendAutoNews(params)
+// runSchedule dipanggil gocron setiap kali jadwal jatuh tempo di zona waktu location
+func (cs *CronService) runSchedule(schedule repository.Schedule, location *time.Location) {
+	cs.executeSchedule(schedule, location, false)
 }
 
-// Job untuk mengirim berita test immediate (setiap menit untuk debugging)
-// func (cs *CronService) sendImmediateTestNews() {
-// 	wibTime := cs.getWIBTime()
-// 	log.Printf("⚡ [IMMEDIATE TEST] Sending immediate test news... (WIB: %s)", wibTime.Format("15:04:05"))
-// 	cs.sendAutoNews("⚡ **Immediate Test News - Every Minute**")
-// }
+// executeSchedule mengirim berita jadwal, mencatat hasilnya di riwayat run dan,
+// jika sukses, menyimpan waktu run sukses terakhir untuk deteksi catch-up
+func (cs *CronService) executeSchedule(schedule repository.Schedule, location *time.Location, catchUp bool) {
+	startedAt := time.Now()
+	log.Printf("📅 [AUTO NEWS] Running schedule '%s' (%s)... (%s)", schedule.Name, schedule.ID, startedAt.In(location).Format("15:04 MST"))
+
+	var channels, posted int
+	var err error
+	if schedule.Kind == repository.ScheduleKindNews {
+		channels, posted, err = cs.sendAutoNews(schedule, location)
+	} else {
+		channels, posted, err = cs.sendDigest(schedule, location)
+	}
+
+	record := RunRecord{
+		ScheduleID:     schedule.ID,
+		Name:           schedule.Name,
+		Timezone:       location.String(),
+		StartedAt:      startedAt,
+		Duration:       time.Since(startedAt),
+		Success:        err == nil,
+		Channels:       channels,
+		ArticlesPosted: posted,
+		CatchUp:        catchUp,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	cs.runs.Add(record)
+
+	if err == nil {
+		if err := cs.schedules.RecordSuccess(schedule.ID, location.String(), startedAt); err != nil {
+			log.Printf("⚠️ [AUTO NEWS] Failed to record successful run of '%s': %v", schedule.Name, err)
+		}
+	}
+}
 
-// Fungsi utama untuk mengambil dan mengirim berita
-func (cs *CronService) sendAutoNews(header string) {
+// Fungsi utama untuk mengambil dan mengirim berita ke channel tujuan jadwal.
+// Mengembalikan jumlah channel tujuan dan total artikel yang terkirim.
+func (cs *CronService) sendAutoNews(schedule repository.Schedule, location *time.Location) (int, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
-	// Ambil berita teknologi terbaru
-	newsResponse, err := cs.newsService.FetchTechNews(ctx)
+	channelIDs := cs.targetChannels(schedule, location)
+	if len(channelIDs) == 0 {
+		log.Printf("⚠️ [AUTO NEWS] No target channel for schedule '%s'", schedule.Name)
+		return 0, 0, errNoTargetChannel
+	}
+
+	// Ambil berita teknologi terbaru, atau hasil pencarian jika jadwal punya query
+	news, err := cs.fetchScheduleNews(ctx, schedule)
 	if err != nil {
 		log.Printf("❌ [AUTO NEWS] Error getting news: %v", err)
 		// Kirim pesan error ke Discord
-		errorMsg := "❌ **Tech News Update**\n\nMaaf, terjadi kesalahan saat mengambil berita teknologi terbaru. Silakan coba lagi nanti."
-		cs.sendToDiscord(errorMsg)
-		return
+		_, reason := DescribeNewsError(err)
+		errorMsg := "❌ **Tech News Update**\n\nMaaf, berita teknologi terbaru belum bisa diambil: " + reason + ". Silakan coba lagi nanti."
+		for _, channelID := range channelIDs {
+			cs.sendToDiscord(channelID, response.TextMessage(errorMsg))
+		}
+		return len(channelIDs), 0, err
 	}
 
-	// Format pesan untuk Discord
-	message := cs.formatNewsMessage(header, newsResponse)
+	if err := cs.history.RecordSeen(news); err != nil {
+		log.Printf("⚠️ [AUTO NEWS] Failed to record seen articles: %v", err)
+	}
 
-	// Kirim ke Discord
-	cs.sendToDiscord(message)
+	var (
+		posted  int
+		sendErr error
+		failed  int
+	)
+	for _, channelID := range channelIDs {
+		count, err := cs.postToChannel(channelID, schedule.Header, news, location)
+		if err != nil {
+			sendErr = err
+			failed++
+			continue
+		}
+		posted += count
+	}
 
-	log.Println("✅ [AUTO NEWS] News sent successfully to Discord")
+	// Run dianggap gagal hanya jika tidak ada satu pun channel yang berhasil dikirimi
+	if failed == len(channelIDs) {
+		return len(channelIDs), posted, sendErr
+	}
+	return len(channelIDs), posted, nil
 }
 
-// Format pesan berita untuk Discord
-func (cs *CronService) formatNewsMessage(header string, newsResponse *NewsResponse) string {
-	if newsResponse == nil || len(newsResponse.News) == 0 {
-		return header + "\n\n❌ Tidak ada berita teknologi terbaru yang tersedia saat ini."
+// sendDigest mengirim ranking top story dari riwayat artikel ke channel tujuan jadwal.
+// Digest tidak difilter atau dicatat sebagai posting baru, karena isinya memang
+// rangkuman artikel yang sudah pernah dikirim.
+func (cs *CronService) sendDigest(schedule repository.Schedule, location *time.Location) (int, int, error) {
+	channelIDs := cs.targetChannels(schedule, location)
+	if len(channelIDs) == 0 {
+		log.Printf("⚠️ [DIGEST] No target channel for schedule '%s'", schedule.Name)
+		return 0, 0, errNoTargetChannel
+	}
+
+	now := time.Now().In(location)
+	since := now.AddDate(0, 0, -7)
+	if schedule.Kind == repository.ScheduleKindMonthlyDigest {
+		since = now.AddDate(0, -1, 0)
 	}
 
-	// Gunakan formatter yang sudah ada di NewsService
-	formattedNews := cs.newsService.FormatNewsForDiscord(newsResponse.News)
+	sections, err := cs.digest.TopStories(since, DefaultDigestLimit)
+	if err != nil {
+		log.Printf("❌ [DIGEST] Failed to rank stories: %v", err)
+		return len(channelIDs), 0, err
+	}
+
+	stories := 0
+	for _, section := range sections {
+		stories += len(section.Entries)
+	}
+
+	header := fmt.Sprintf("%s\n📅 %s - %s", schedule.Header, since.Format("2 Jan"), now.Format("2 Jan 2006"))
+	message := cs.renderer.RenderDigest(header, sections)
 
-	// Use WIB time for the timestamp
-	wibTime := cs.getWIBTime()
-	message := header + "\n\n" + formattedNews
-	message += "\n\n---\n🤖 *Auto News Update* • " + wibTime.Format("15:04 WIB")
+	sent := 0
+	var sendErr error
+	for _, channelID := range channelIDs {
+		if _, err := cs.discordBot.SendMessage(channelID, message); err != nil {
+			log.Printf("❌ [DIGEST] Failed to send to Discord channel %s: %v", channelID, err)
+			sendErr = err
+			continue
+		}
+		sent++
+	}
+	log.Printf("✅ [DIGEST] '%s' sent to %d/%d channels (%d stories)", schedule.Name, sent, len(channelIDs), stories)
 
-	return message
+	if sent == 0 {
+		return len(channelIDs), 0, sendErr
+	}
+	return len(channelIDs), stories * sent, nil
 }
 
-// Kirim pesan ke Discord channel
-func (cs *CronService) sendToDiscord(message string) {
-	// Channel names - coba beberapa kemungkinan format
-	channelNames := []string{
-		"🔥┃ai-tech-news", // Format dengan emoji separator
-		"ai-tech-news",   // Format simple
-		"tech-news",      // Format alternatif
-		"general",        // Fallback ke general channel
+// pruneHistory menghapus riwayat artikel yang sudah melewati retention
+func (cs *CronService) pruneHistory() {
+	pruned, err := cs.digest.Prune(cs.retention)
+	if err != nil {
+		log.Printf("⚠️ [HISTORY] Failed to prune article history: %v", err)
+		return
 	}
+	log.Printf("🧹 [HISTORY] Pruned %d articles older than %s", pruned, cs.retention)
+}
 
-	var lastErr error
-	for _, channelName := range channelNames {
-		err := cs.discordBot.SendNewsToChannel(channelName, message)
-		if err == nil {
-			log.Printf("✅ [AUTO NEWS] Message sent to Discord channel '%s' successfully", channelName)
-			return
+func (cs *CronService) fetchScheduleNews(ctx context.Context, schedule repository.Schedule) ([]repository.News, error) {
+	if schedule.Query != "" {
+		results, err := cs.newsService.SearchNews(ctx, repository.SearchOptions{Keyword: schedule.Query})
+		if err != nil {
+			return nil, err
 		}
-		lastErr = err
-		log.Printf("⚠️ [AUTO NEWS] Failed to send to channel '%s': %v", channelName, err)
+		return results.News, nil
 	}
 
-	log.Printf("❌ [AUTO NEWS] Failed to send to any Discord channel: %v", lastErr)
+	newsResponse, err := cs.newsService.FetchTechNews(ctx, repository.SearchOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return newsResponse.News, nil
 }
 
-// Hello World job untuk testing
-func (cs *CronService) helloWorldJob() {
-	log.Printf("👋 [HELLO WORLD] Hello World! - %s", time.Now().Format("15:04:05"))
+// postToChannel mengirim artikel yang belum pernah dikirim ke channel tersebut
+// dan mengembalikan jumlah artikel yang terkirim
+func (cs *CronService) postToChannel(channelID string, header string, news []repository.News, location *time.Location) (int, error) {
+	// Buang artikel yang sudah pernah dikirim ke channel ini
+	since := time.Now().Add(-cs.repostWindow)
+	freshNews, err := cs.history.FilterUnposted(channelID, news, since)
+	if err != nil {
+		log.Printf("⚠️ [AUTO NEWS] Failed to check posted history, sending all articles: %v", err)
+		freshNews = news
+	}
+
+	if len(news) > 0 && len(freshNews) == 0 {
+		log.Printf("ℹ️ [AUTO NEWS] All %d articles were already posted to %s within %s, skipping", len(news), channelID, cs.repostWindow)
+		return 0, nil
+	}
+
+	// Format pesan untuk Discord
+	message := cs.formatNewsMessage(header, &NewsResponse{News: freshNews}, location)
+
+	// Kirim ke Discord
+	messageID, err := cs.discordBot.SendMessage(channelID, message)
+	if err != nil {
+		log.Printf("❌ [AUTO NEWS] Failed to send to Discord channel %s: %v", channelID, err)
+		return 0, err
+	}
+	log.Printf("✅ [AUTO NEWS] Message sent to Discord channel %s successfully", channelID)
+
+	// Hanya artikel yang benar-benar tampil di pesan yang dicatat sebagai terkirim
+	posted := freshNews
+	if len(posted) > response.MaxEmbedArticles {
+		posted = posted[:response.MaxEmbedArticles]
+	}
+	if err := cs.history.RecordPosted(channelID, messageID, posted, time.Now()); err != nil {
+		log.Printf("⚠️ [AUTO NEWS] Failed to record posted articles: %v", err)
+	}
+	return len(posted), nil
 }
 
-// Ping start endpoint setiap menit untuk menjaga service tetap aktif
-func (cs *CronService) pingStartEndpoint() {
-	// Get server URL from environment or use default
-	serverURL := os.Getenv("SERVER_URL")
-	if serverURL == "" {
-		serverURL = "http://localhost:8080" // Default local server
+// Format pesan berita untuk Discord sebagai embed
+func (cs *CronService) formatNewsMessage(header string, newsResponse *NewsResponse, location *time.Location) *response.DiscordMessage {
+	if newsResponse == nil || len(newsResponse.News) == 0 {
+		return response.TextMessage(header + "\n\n❌ Tidak ada berita teknologi terbaru yang tersedia saat ini.")
 	}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	newsResp := response.NewNewsResponse().
+		WithNews(newsResponse.News).
+		Build().(*response.NewsResponse)
+
+	// Timestamp memakai zona waktu jadwal
+	content := header + "\n🤖 *Auto News Update* • " + time.Now().In(location).Format("15:04 MST")
+	embeds := cs.renderer.RenderItems(newsResp.News, response.EmbedColorNews)
+	if len(embeds) > 1 {
+		content += fmt.Sprintf("\n👍 *Beri reaksi %s-%s sesuai urutan artikel favoritmu untuk ranking digest*", ReactionNumbers[0], ReactionNumbers[len(embeds)-1])
+	}
+	return &response.DiscordMessage{
+		Content: content,
+		Embeds:  embeds,
 	}
+}
 
-	// Make POST request to /start endpoint
-	resp, err := client.Post(serverURL+"/start", "application/json", nil)
-	if err != nil {
-		wibTime := cs.getWIBTime()
-		log.Printf("⚠️ [HEALTH CHECK] Failed to ping /start endpoint: %v (WIB: %s)", err, wibTime.Format("15:04:05"))
-		return
+// targetChannels mengembalikan channel tujuan jadwal. Jadwal dengan ChannelID hanya
+// dikirim ke channel itu; selain itu dikirim ke setiap guild yang mengaktifkan jadwal
+// dan, untuk jadwal tanpa Timezone sendiri, yang zona waktunya sama dengan location.
+// Guild tanpa news channel yang diatur memakai DefaultNewsChannelNames di guild itu sendiri.
+func (cs *CronService) targetChannels(schedule repository.Schedule, location *time.Location) []string {
+	if schedule.ChannelID != "" {
+		return []string{schedule.ChannelID}
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	wibTime := cs.getWIBTime()
-	if resp.StatusCode == http.StatusOK {
-		log.Printf("✅ [HEALTH CHECK] Successfully pinged /start endpoint (WIB: %s)", wibTime.Format("15:04:05"))
-	} else {
-		log.Printf("⚠️ [HEALTH CHECK] /start endpoint returned status %d (WIB: %s)", resp.StatusCode, wibTime.Format("15:04:05"))
+	var channelIDs []string
+
+	for _, guildID := range cs.discordBot.GuildIDs() {
+		if schedule.GuildID != "" && schedule.GuildID != guildID {
+			continue
+		}
+		if schedule.Timezone == "" && cs.guildLocation(guildID).String() != location.String() {
+			continue
+		}
+
+		config, err := cs.guildConfig.Get(guildID)
+		if err != nil {
+			log.Printf("⚠️ [AUTO NEWS] Failed to load config for guild %s: %v", guildID, err)
+			continue
+		}
+
+		if !config.ScheduleEnabled(schedule.Name) {
+			continue
+		}
+
+		if len(config.NewsChannelIDs) > 0 {
+			channelIDs = append(channelIDs, config.NewsChannelIDs...)
+			continue
+		}
+
+		for _, channelName := range DefaultNewsChannelNames {
+			channelID, err := cs.discordBot.FindChannelID(guildID, channelName)
+			if err == nil {
+				channelIDs = append(channelIDs, channelID)
+				break
+			}
+		}
 	}
+
+	return channelIDs
+}
+
+// Kirim pesan ke Discord channel
+func (cs *CronService) sendToDiscord(channelID string, message *response.DiscordMessage) bool {
+	if _, err := cs.discordBot.SendMessage(channelID, message); err != nil {
+		log.Printf("❌ [AUTO NEWS] Failed to send to Discord channel %s: %v", channelID, err)
+		return false
+	}
+
+	log.Printf("✅ [AUTO NEWS] Message sent to Discord channel %s successfully", channelID)
+	return true
+}
+
+// Hello World job untuk testing
+func (cs *CronService) helloWorldJob() {
+	log.Printf("👋 [HELLO WORLD] Hello World! - %s", time.Now().Format("15:04:05"))
 }
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+func TestCheckMinInterval(t *testing.T) {
+	jakarta := mustLoadLocation(t, "Asia/Jakarta")
+
+	tests := []struct {
+		cron    string
+		wantErr bool
+	}{
+		{"0 8 * * *", false},
+		{"0 8,13,17 * * *", false},
+		{"0 */2 * * *", false},
+		{"0 16 * * 5", false},
+		{"0 9 1 * *", false},
+		{"0 * * * *", false},
+		{"* * * * *", true},
+		{"*/30 * * * *", true},
+		{"0,30 9 * * 1", true},
+		{"0 8 * * *x", true},
+	}
+
+	for _, tt := range tests {
+		err := checkMinInterval(tt.cron, jakarta, time.Hour)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkMinInterval(%q) err = %v, wantErr %t", tt.cron, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("checkMinInterval(%q) err = %v, want ErrInvalidSchedule", tt.cron, err)
+		}
+	}
+
+	if err := checkMinInterval("* * * * *", jakarta, 0); err != nil {
+		t.Errorf("zero minimum should allow any schedule: %v", err)
+	}
+}
+
+func TestAddScheduleEnforcesMinInterval(t *testing.T) {
+	cs, _ := newTestCronService(t, CronOptions{MinInterval: time.Hour})
+
+	_, err := cs.AddSchedule(repository.Schedule{Name: "spam", CronExpr: "* * * * *", GuildID: "guild-1"})
+	if !errors.Is(err, ErrInvalidSchedule) {
+		t.Fatalf("err = %v, want ErrInvalidSchedule", err)
+	}
+
+	schedules, _ := cs.ListSchedules()
+	if len(schedules) != 0 {
+		t.Errorf("rejected schedule was saved: %+v", schedules)
+	}
+}
+
+func TestAddScheduleRequiresUniqueNames(t *testing.T) {
+	cs, _ := newTestCronService(t, CronOptions{MinInterval: time.Hour},
+		repository.Schedule{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"},
+	)
+
+	add := func(name, guildID string) error {
+		_, err := cs.AddSchedule(repository.Schedule{Name: name, CronExpr: "0 9 * * *", GuildID: guildID})
+		return err
+	}
+
+	if err := add("standup", "guild-1"); err != nil {
+		t.Fatalf("first standup: %v", err)
+	}
+	if err := add("Standup", "guild-1"); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("duplicate name in the same guild: err = %v", err)
+	}
+	if err := add("standup", "guild-2"); err != nil {
+		t.Errorf("same name in another guild should be allowed: %v", err)
+	}
+	if err := add("morning", "guild-1"); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("guild schedule shadowing a global one: err = %v", err)
+	}
+	if err := add("standup", ""); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("global schedule clashing with a guild one: err = %v", err)
+	}
+}
+
+func TestAddScheduleValidatesChannel(t *testing.T) {
+	cs, _ := newTestCronService(t, CronOptions{})
+	if err := cs.Start(newFakeBot("guild-1", "guild-2")); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		guildID   string
+		channelID string
+		wantErr   bool
+	}{
+		{"no-channel", "", "", false},
+		{"own-channel", "guild-1", "guild-1-news", false},
+		{"no-guild", "", "guild-1-news", true},
+		{"other-guild", "guild-2", "guild-1-news", true},
+		{"typo", "guild-1", "guild-9-news", true},
+	}
+	for _, tt := range tests {
+		_, err := cs.AddSchedule(repository.Schedule{Name: tt.name, CronExpr: "0 9 * * *", GuildID: tt.guildID, ChannelID: tt.channelID})
+		if tt.wantErr && !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("%s: err = %v, want ErrInvalidSchedule", tt.name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+	}
+
+	schedules, _ := cs.ListSchedules()
+	if len(schedules) != 2 {
+		t.Errorf("saved %d schedules, want only the 2 valid ones", len(schedules))
+	}
+}
+
+// failingSaves adalah ScheduleRepository yang menolak setiap SaveSchedule
+type failingSaves struct {
+	repository.ScheduleRepository
+}
+
+func (failingSaves) SaveSchedule(*repository.Schedule) error {
+	return errors.New("disk full")
+}
+
+func TestSetPausedKeepsJobsInSyncWhenSaveFails(t *testing.T) {
+	cs, repo := newTestCronService(t, CronOptions{},
+		repository.Schedule{ID: "active", Name: "active", CronExpr: "0 8 * * *"},
+		repository.Schedule{ID: "paused", Name: "paused", CronExpr: "0 9 * * *", Paused: true},
+	)
+	if err := cs.Start(newFakeBot("guild-1")); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	cs.schedules = failingSaves{repo}
+
+	if _, err := cs.ResumeSchedule("paused"); err == nil {
+		t.Fatal("ResumeSchedule should fail when the save fails")
+	}
+	if got := jobLocations(cs, "paused"); len(got) != 0 {
+		t.Errorf("failed resume left jobs registered: %v", got)
+	}
+
+	if _, err := cs.PauseSchedule("active"); err == nil {
+		t.Fatal("PauseSchedule should fail when the save fails")
+	}
+	if got := jobLocations(cs, "active"); len(got) == 0 {
+		t.Error("failed pause removed the job of a schedule that is still active")
+	}
+}
+
+// jobLocations mengembalikan zona waktu job aktif untuk satu jadwal
+func jobLocations(cs *CronService, scheduleID string) map[string]bool {
+	locations := make(map[string]bool)
+	for _, status := range cs.JobStatuses() {
+		if status.ScheduleID == scheduleID {
+			locations[status.Location.String()] = true
+		}
+	}
+	return locations
+}
+
+func TestSchedulesFollowGuildTimezones(t *testing.T) {
+	cs, _ := newTestCronService(t, CronOptions{},
+		repository.Schedule{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"},
+	)
+	bot := newFakeBot("guild-1")
+	if err := cs.Start(bot); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if got := jobLocations(cs, "morning"); len(got) != 1 || !got["Asia/Jakarta"] {
+		t.Fatalf("locations after start = %v", got)
+	}
+
+	// Zona waktu guild yang sudah ada berubah: job di zona baru langsung dibuat
+	if _, err := cs.guildConfig.SetTimezone("guild-1", "Asia/Makassar"); err != nil {
+		t.Fatalf("SetTimezone: %v", err)
+	}
+	if got := jobLocations(cs, "morning"); !got["Asia/Makassar"] {
+		t.Errorf("locations after timezone change = %v, want Asia/Makassar", got)
+	}
+
+	// Guild baru dengan override zona waktu bergabung
+	if _, err := cs.guildConfig.SetTimezone("guild-2", "Asia/Tokyo"); err != nil {
+		t.Fatalf("SetTimezone: %v", err)
+	}
+	if got := jobLocations(cs, "morning"); got["Asia/Tokyo"] {
+		t.Fatalf("guild-2 has not joined yet, got %v", got)
+	}
+	bot.join("guild-2")
+	cs.GuildJoined("guild-2")
+	if got := jobLocations(cs, "morning"); !got["Asia/Tokyo"] || !got["Asia/Makassar"] {
+		t.Errorf("locations after guild join = %v, want Asia/Tokyo and Asia/Makassar", got)
+	}
+}
+
+func TestChannelScheduleRegisteredOncePerChannel(t *testing.T) {
+	cs, _ := newTestCronService(t, CronOptions{},
+		repository.Schedule{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"},
+		// Jadwal lama dari admin API: channel tanpa guild dan tanpa timezone
+		repository.Schedule{ID: "ai-daily", Name: "ai-daily", CronExpr: "0 9 * * *", ChannelID: "guild-2-news"},
+	)
+	for guildID, timezone := range map[string]string{"guild-1": "Asia/Makassar", "guild-2": "Asia/Tokyo"} {
+		if _, err := cs.guildConfig.SetTimezone(guildID, timezone); err != nil {
+			t.Fatalf("SetTimezone: %v", err)
+		}
+	}
+	if err := cs.Start(newFakeBot("guild-1", "guild-2")); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if got := jobLocations(cs, "morning"); len(got) != 3 {
+		t.Fatalf("global schedule locations = %v, want the default and both guild zones", got)
+	}
+	if got := jobLocations(cs, "ai-daily"); len(got) != 1 || !got["Asia/Tokyo"] {
+		t.Errorf("channel schedule locations = %v, want only the channel guild's Asia/Tokyo", got)
+	}
+	if got := countJobs(cs, "ai-daily"); got != 1 {
+		t.Errorf("channel schedule has %d jobs, want 1", got)
+	}
+}
+
+func TestScheduleChangesRequireLeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leader.json")
+	leader, err := repository.NewFileLease(path, "replica-a", time.Minute)
+	if err != nil {
+		t.Fatalf("NewFileLease: %v", err)
+	}
+	follower, err := repository.NewFileLease(path, "replica-b", time.Minute)
+	if err != nil {
+		t.Fatalf("NewFileLease: %v", err)
+	}
+	if err := leader.IsLeader(context.Background()); err != nil {
+		t.Fatalf("IsLeader: %v", err)
+	}
+
+	morning := repository.Schedule{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"}
+	cs, repo := newTestCronService(t, CronOptions{Elector: follower}, morning)
+
+	if _, err := cs.AddSchedule(repository.Schedule{Name: "standup", CronExpr: "0 9 * * *"}); !errors.Is(err, repository.ErrNotLeader) {
+		t.Errorf("AddSchedule on follower = %v, want ErrNotLeader", err)
+	}
+	if _, err := cs.PauseSchedule("morning"); !errors.Is(err, repository.ErrNotLeader) {
+		t.Errorf("PauseSchedule on follower = %v, want ErrNotLeader", err)
+	}
+	if _, err := cs.RemoveSchedule("morning"); !errors.Is(err, repository.ErrNotLeader) {
+		t.Errorf("RemoveSchedule on follower = %v, want ErrNotLeader", err)
+	}
+	if schedule, err := repo.GetSchedule("morning"); err != nil || schedule.Paused {
+		t.Errorf("follower changed the schedule: %+v, %v", schedule, err)
+	}
+
+	if state := cs.State(); state != SchedulerStopped {
+		t.Errorf("State before Start = %s, want %s", state, SchedulerStopped)
+	}
+	if err := cs.Start(newFakeBot()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if state := cs.State(); state != SchedulerNotLeader {
+		t.Errorf("State on follower = %s, want %s", state, SchedulerNotLeader)
+	}
+
+	// Setelah leader melepas lease, follower mengambil alih dan perubahan diterima
+	if err := leader.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, err := cs.PauseSchedule("morning"); err != nil {
+		t.Errorf("PauseSchedule after takeover: %v", err)
+	}
+	if state := cs.State(); state != SchedulerRunning {
+		t.Errorf("State after takeover = %s, want %s", state, SchedulerRunning)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+// titleSimilarityThreshold adalah batas Jaccard similarity judul agar dua artikel
+// dianggap meliput berita yang sama
+const titleSimilarityThreshold = 0.5
+
+var titleStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "of": true,
+	"to": true, "in": true, "on": true, "for": true, "with": true, "is": true,
+	"are": true, "its": true, "it": true, "at": true, "by": true, "from": true,
+	"as": true, "new": true, "after": true, "says": true,
+}
+
+// dedupNews menggabungkan artikel dengan URL kanonik yang sama atau judul yang
+// hampir identik. Artikel pertama (ranking tertinggi) menjadi perwakilan dan
+// sumber lainnya dicatat di RelatedSources. URL kanonik hanya dipakai sebagai
+// kunci; URL artikel tetap yang asli dari sumber karena versi kanoniknya
+// (misal https paksa atau tanpa /amp/) belum tentu dilayani situsnya.
+func dedupNews(news []repository.News) []repository.News {
+	type cluster struct {
+		article repository.News
+		tokens  map[string]bool
+		sources map[string]bool
+	}
+
+	var clusters []*cluster
+	byURL := make(map[string]*cluster)
+
+	for _, article := range news {
+		key := repository.CanonicalizeURL(article.URL)
+		tokens := titleTokens(article.Title)
+
+		target := byURL[key]
+		if target == nil {
+			for _, c := range clusters {
+				if jaccard(c.tokens, tokens) >= titleSimilarityThreshold {
+					target = c
+					break
+				}
+			}
+		}
+
+		if target == nil {
+			c := &cluster{
+				article: article,
+				tokens:  tokens,
+				sources: map[string]bool{article.Source: true},
+			}
+			clusters = append(clusters, c)
+			byURL[key] = c
+			continue
+		}
+
+		byURL[key] = target
+		if article.Score > target.article.Score {
+			target.article.Score = article.Score
+			target.article.Comments = article.Comments
+		}
+		if article.Source != "" && !target.sources[article.Source] {
+			target.sources[article.Source] = true
+			target.article.RelatedSources = append(target.article.RelatedSources, article.Source)
+		}
+	}
+
+	result := make([]repository.News, len(clusters))
+	for i, c := range clusters {
+		sort.Strings(c.article.RelatedSources)
+		result[i] = c.article
+	}
+	return result
+}
+
+// titleTokens memecah judul menjadi set kata (lowercase, tanpa tanda baca dan stopword)
+func titleTokens(title string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make(map[string]bool, len(words))
+	for _, word := range words {
+		if !titleStopwords[word] {
+			tokens[word] = true
+		}
+	}
+	return tokens
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for token := range a {
+		if b[token] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
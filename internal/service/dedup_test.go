@@ -0,0 +1,52 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+func TestDedupNewsKeepsOriginalURL(t *testing.T) {
+	news := []repository.News{
+		{Title: "OpenAI releases GPT-5", URL: "http://www.example.com/amp/gpt5?utm_source=rss&id=7", Source: "Example", Score: 10},
+		{Title: "Totally different", URL: "https://example.com/gpt5?id=7", Source: "Mirror", Score: 50, Comments: 12},
+	}
+
+	got := dedupNews(news)
+	if len(got) != 1 {
+		t.Fatalf("got %d articles, want 1 cluster: %+v", len(got), got)
+	}
+	if got[0].URL != news[0].URL {
+		t.Errorf("URL = %q, want original %q", got[0].URL, news[0].URL)
+	}
+	if got[0].Score != 50 || got[0].Comments != 12 {
+		t.Errorf("score/comments = %d/%d, want best of cluster 50/12", got[0].Score, got[0].Comments)
+	}
+	if !reflect.DeepEqual(got[0].RelatedSources, []string{"Mirror"}) {
+		t.Errorf("related sources = %v", got[0].RelatedSources)
+	}
+}
+
+func TestDedupNewsClustersSimilarTitles(t *testing.T) {
+	news := []repository.News{
+		{Title: "Apple announces Vision Pro 2 headset", URL: "https://a.com/1", Source: "The Verge"},
+		{Title: "Rust lands in the Linux kernel", URL: "https://b.com/2", Source: "Ars Technica"},
+		{Title: "Apple announces the Vision Pro 2", URL: "https://c.com/3", Source: "Wired"},
+		{Title: "Vision Pro 2 headset announced by Apple", URL: "https://d.com/4", Source: "Engadget"},
+	}
+
+	got := dedupNews(news)
+	if len(got) != 2 {
+		t.Fatalf("got %d clusters, want 2: %+v", len(got), got)
+	}
+	if got[0].Source != "The Verge" {
+		t.Errorf("representative = %q, want first (highest ranked) article", got[0].Source)
+	}
+	if want := []string{"Engadget", "Wired"}; !reflect.DeepEqual(got[0].RelatedSources, want) {
+		t.Errorf("related sources = %v, want %v", got[0].RelatedSources, want)
+	}
+	if len(got[1].RelatedSources) != 0 {
+		t.Errorf("unrelated story got related sources %v", got[1].RelatedSources)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+)
+
+// Bobot ranking digest: artikel yang sering dikirim dan banyak direaksi user lebih
+// diutamakan; skor sumber (misal points Hacker News) memakai log agar tidak mendominasi
+const (
+	digestPostWeight     = 2.0
+	digestReactionWeight = 3.0
+
+	// DefaultDigestLimit adalah jumlah top story dalam satu digest
+	DefaultDigestLimit = 15
+)
+
+// digestTopic adalah kelompok topik digest beserta kata kunci judul/deskripsinya.
+// Topik dicek berurutan; artikel masuk ke topik pertama yang cocok.
+type digestTopic struct {
+	Name     string
+	Keywords []string
+}
+
+var digestTopics = []digestTopic{
+	{Name: "🤖 AI & Machine Learning", Keywords: []string{"ai", "llm", "gpt", "openai", "anthropic", "claude", "gemini", "model", "models", "neural", "machine", "learning", "chatbot", "agent", "agents", "inference"}},
+	{Name: "🔐 Security", Keywords: []string{"security", "vulnerability", "breach", "hack", "hacked", "hacker", "malware", "ransomware", "exploit", "cve", "phishing", "privacy", "leak"}},
+	{Name: "☁️ Cloud & DevOps", Keywords: []string{"cloud", "aws", "azure", "gcp", "kubernetes", "docker", "devops", "serverless", "outage", "datacenter", "infrastructure"}},
+	{Name: "💼 Startup & Bisnis", Keywords: []string{"startup", "funding", "raises", "acquires", "acquisition", "ipo", "layoffs", "revenue", "valuation", "antitrust", "lawsuit", "regulation"}},
+	{Name: "📱 Hardware & Gadget", Keywords: []string{"iphone", "android", "apple", "samsung", "chip", "chips", "gpu", "nvidia", "intel", "amd", "laptop", "smartphone", "hardware", "device"}},
+	{Name: "💻 Programming", Keywords: []string{"programming", "rust", "golang", "python", "javascript", "typescript", "java", "compiler", "framework", "library", "github", "open-source", "opensource", "developer", "developers"}},
+}
+
+const digestOtherTopic = "📰 Lainnya"
+
+// DigestService menyusun digest top story dari riwayat artikel yang tersimpan,
+// jauh melewati window 24 jam yang dipakai FetchTechNews
+type DigestService struct {
+	history repository.HistoryRepository
+}
+
+func NewDigestService(history repository.HistoryRepository) *DigestService {
+	return &DigestService{history: history}
+}
+
+// TopStories meranking artikel yang aktif sejak waktu since, mengambil limit artikel
+// teratas lalu mengelompokkannya per topik. Section diurutkan berdasarkan artikel
+// terbaik di dalamnya.
+func (d *DigestService) TopStories(since time.Time, limit int) ([]response.DigestSection, error) {
+	records, err := d.history.ArticlesSince(since)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		ri, rj := digestRank(records[i]), digestRank(records[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return records[i].News.PublishedAt.After(records[j].News.PublishedAt)
+	})
+
+	if limit <= 0 {
+		limit = DefaultDigestLimit
+	}
+	if len(records) > limit {
+		records = records[:limit]
+	}
+
+	var sections []response.DigestSection
+	index := make(map[string]int)
+	for _, record := range records {
+		topic := classifyTopic(record.News)
+		i, ok := index[topic]
+		if !ok {
+			i = len(sections)
+			index[topic] = i
+			sections = append(sections, response.DigestSection{Topic: topic})
+		}
+		sections[i].Entries = append(sections[i].Entries, response.DigestEntry{
+			Title:     record.News.Title,
+			URL:       record.News.URL,
+			Source:    record.News.Source,
+			Posts:     record.PostCount,
+			Reactions: record.Reactions,
+			Score:     record.News.Score,
+		})
+	}
+
+	return sections, nil
+}
+
+// ReactionNumbers adalah emoji keycap untuk memilih artikel ke-n di pesan berita bot,
+// sesuai urutan embed
+var ReactionNumbers = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣"}
+
+// RecordReaction mencatat reaksi user pada pesan berita yang dikirim bot. Reaksi
+// angka (1️⃣-5️⃣) dihitung ke artikel di urutan itu; emoji lain hanya dihitung
+// jika pesan berisi satu artikel, agar satu reaksi tidak menaikkan semua artikel.
+func (d *DigestService) RecordReaction(messageID, emoji string, delta int) error {
+	return d.history.RecordReaction(messageID, reactionArticle(emoji), delta)
+}
+
+// reactionArticle mengembalikan indeks artikel untuk emoji angka, atau -1.
+// Discord kadang mengirim keycap tanpa variation selector (U+FE0F).
+func reactionArticle(emoji string) int {
+	emoji = strings.ReplaceAll(emoji, "\uFE0F", "")
+	for i, number := range ReactionNumbers {
+		if emoji == strings.ReplaceAll(number, "\uFE0F", "") {
+			return i
+		}
+	}
+	return -1
+}
+
+// Prune menghapus riwayat artikel yang lebih tua dari retention
+func (d *DigestService) Prune(retention time.Duration) (int, error) {
+	return d.history.Prune(time.Now().Add(-retention))
+}
+
+func digestRank(record repository.ArticleRecord) float64 {
+	return float64(record.PostCount)*digestPostWeight +
+		float64(record.Reactions)*digestReactionWeight +
+		math.Log1p(float64(max(record.News.Score, 0)))
+}
+
+// classifyTopic menentukan topik artikel dari kata kunci di judul dan deskripsi
+func classifyTopic(news repository.News) string {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(news.Title+" "+news.Description), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-')
+	}) {
+		words[word] = true
+	}
+
+	for _, topic := range digestTopics {
+		for _, keyword := range topic.Keywords {
+			if words[keyword] {
+				return topic.Name
+			}
+		}
+	}
+	return digestOtherTopic
+}
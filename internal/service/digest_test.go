@@ -0,0 +1,23 @@
+package service
+
+import "testing"
+
+func TestReactionArticle(t *testing.T) {
+	tests := []struct {
+		emoji string
+		want  int
+	}{
+		{"1️⃣", 0},
+		{"5️⃣", 4},
+		{"3⃣", 2}, // tanpa variation selector
+		{"👍", -1},
+		{"6️⃣", -1},
+		{"", -1},
+	}
+
+	for _, tt := range tests {
+		if got := reactionArticle(tt.emoji); got != tt.want {
+			t.Errorf("reactionArticle(%q) = %d, want %d", tt.emoji, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+// Nama channel yang dipakai jika guild belum mengatur channel lewat /config
+var (
+	DefaultNewsChannelNames = []string{
+		"🔥┃ai-tech-news", // Format dengan emoji separator
+		"ai-tech-news",   // Format simple
+		"tech-news",      // Format alternatif
+		"general",        // Fallback ke general channel
+	}
+	DefaultCommandChannelNames = []string{
+		"🔥┃ai-tech-news",
+		"🕹️┃dev-talk",
+	}
+)
+
+var (
+	// ErrInvalidTimezone dikembalikan jika nama zona waktu bukan zona IANA yang valid
+	ErrInvalidTimezone = errors.New("invalid timezone")
+	// ErrUnknownSchedule dikembalikan (sebagai UnknownScheduleError) jika nama jadwal
+	// tidak ada untuk guild tersebut
+	ErrUnknownSchedule = errors.New("unknown schedule")
+)
+
+// UnknownScheduleError berisi nama jadwal yang ditolak SetSchedules
+type UnknownScheduleError struct {
+	Names []string
+}
+
+func (e *UnknownScheduleError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrUnknownSchedule, strings.Join(e.Names, ", "))
+}
+
+func (e *UnknownScheduleError) Unwrap() error {
+	return ErrUnknownSchedule
+}
+
+// GuildConfigService mengelola konfigurasi channel dan jadwal per guild
+type GuildConfigService struct {
+	repo      repository.GuildConfigRepository
+	schedules repository.ScheduleRepository
+
+	// mu menjaga read-modify-write di update agar admin command yang bersamaan
+	// tidak saling menimpa perubahan
+	mu        sync.Mutex
+	listeners []func(previous, current repository.GuildConfig)
+}
+
+func NewGuildConfigService(repo repository.GuildConfigRepository, schedules repository.ScheduleRepository) *GuildConfigService {
+	return &GuildConfigService{
+		repo:      repo,
+		schedules: schedules,
+	}
+}
+
+// OnChange mendaftarkan fungsi yang dipanggil setiap kali konfigurasi guild tersimpan,
+// dengan nilai sebelum dan sesudah perubahan. Dipanggil di luar lock.
+func (s *GuildConfigService) OnChange(listener func(previous, current repository.GuildConfig)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *GuildConfigService) Get(guildID string) (*repository.GuildConfig, error) {
+	return s.repo.GetGuildConfig(guildID)
+}
+
+// CommandChannelAllowed menentukan apakah bot boleh merespons command di channel ini.
+// Guild yang belum mengatur command channel memakai DefaultCommandChannelNames.
+func (s *GuildConfigService) CommandChannelAllowed(guildID, channelID, channelName string) bool {
+	if guildID == "" {
+		return false
+	}
+
+	config, err := s.repo.GetGuildConfig(guildID)
+	if err != nil {
+		log.Printf("⚠️ Failed to load config for guild %s: %v", guildID, err)
+	} else if len(config.CommandChannelIDs) > 0 {
+		return slices.Contains(config.CommandChannelIDs, channelID)
+	}
+
+	return slices.Contains(DefaultCommandChannelNames, channelName)
+}
+
+func (s *GuildConfigService) AddNewsChannel(guildID, channelID string) (*repository.GuildConfig, error) {
+	return s.update(guildID, func(config *repository.GuildConfig) {
+		config.NewsChannelIDs = appendUnique(config.NewsChannelIDs, channelID)
+	})
+}
+
+func (s *GuildConfigService) RemoveNewsChannel(guildID, channelID string) (*repository.GuildConfig, error) {
+	return s.update(guildID, func(config *repository.GuildConfig) {
+		config.NewsChannelIDs = removeString(config.NewsChannelIDs, channelID)
+	})
+}
+
+func (s *GuildConfigService) AddCommandChannel(guildID, channelID string) (*repository.GuildConfig, error) {
+	return s.update(guildID, func(config *repository.GuildConfig) {
+		config.CommandChannelIDs = appendUnique(config.CommandChannelIDs, channelID)
+	})
+}
+
+func (s *GuildConfigService) RemoveCommandChannel(guildID, channelID string) (*repository.GuildConfig, error) {
+	return s.update(guildID, func(config *repository.GuildConfig) {
+		config.CommandChannelIDs = removeString(config.CommandChannelIDs, channelID)
+	})
+}
+
+// SetSchedules mengatur jadwal yang aktif untuk guild; daftar kosong berarti semua jadwal.
+// Nama yang bukan jadwal global atau jadwal milik guild ini ditolak dengan UnknownScheduleError.
+func (s *GuildConfigService) SetSchedules(guildID string, schedules []string) (*repository.GuildConfig, error) {
+	available, err := s.ScheduleNames(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for _, name := range schedules {
+		if !slices.Contains(available, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, &UnknownScheduleError{Names: unknown}
+	}
+
+	return s.update(guildID, func(config *repository.GuildConfig) {
+		config.EnabledSchedules = schedules
+	})
+}
+
+// ScheduleNames mengembalikan nama jadwal yang bisa diaktifkan guild: jadwal global
+// dan jadwal yang dibuat untuk guild itu sendiri, urut abjad
+func (s *GuildConfigService) ScheduleNames(guildID string) ([]string, error) {
+	schedules, err := s.schedules.ListSchedules()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, schedule := range schedules {
+		if (schedule.GuildID == "" || schedule.GuildID == guildID) && !slices.Contains(names, schedule.Name) {
+			names = append(names, schedule.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SetTimezone mengatur zona waktu IANA (misal "Asia/Makassar") untuk jadwal guild.
+// Nilai kosong atau "default" kembali memakai zona waktu default bot.
+func (s *GuildConfigService) SetTimezone(guildID, timezone string) (*repository.GuildConfig, error) {
+	timezone = strings.TrimSpace(timezone)
+	if strings.EqualFold(timezone, "default") {
+		timezone = ""
+	}
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
+	return s.update(guildID, func(config *repository.GuildConfig) {
+		config.Timezone = timezone
+	})
+}
+
+func (s *GuildConfigService) update(guildID string, apply func(config *repository.GuildConfig)) (*repository.GuildConfig, error) {
+	s.mu.Lock()
+
+	config, err := s.repo.GetGuildConfig(guildID)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	previous := *config
+	apply(config)
+
+	if err := s.repo.SaveGuildConfig(config); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	listeners := s.listeners
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(previous, *config)
+	}
+	return config, nil
+}
+
+func appendUnique(items []string, value string) []string {
+	if slices.Contains(items, value) {
+		return items
+	}
+	return append(items, value)
+}
+
+func removeString(items []string, value string) []string {
+	var result []string
+	for _, item := range items {
+		if item != value {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+func TestSetSchedulesRejectsUnknownNames(t *testing.T) {
+	service, _ := newTestGuildConfigService(t,
+		repository.Schedule{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"},
+		repository.Schedule{ID: "g1-standup", Name: "standup", CronExpr: "0 9 * * 1-5", GuildID: "guild-1"},
+		repository.Schedule{ID: "g2-retro", Name: "retro", CronExpr: "0 16 * * 5", GuildID: "guild-2"},
+	)
+
+	config, err := service.SetSchedules("guild-1", []string{"morning", "standup"})
+	if err != nil {
+		t.Fatalf("SetSchedules: %v", err)
+	}
+	if !reflect.DeepEqual(config.EnabledSchedules, []string{"morning", "standup"}) {
+		t.Errorf("enabled = %v", config.EnabledSchedules)
+	}
+
+	_, err = service.SetSchedules("guild-1", []string{"morning", "retro", "typo"})
+	var unknownErr *UnknownScheduleError
+	if !errors.As(err, &unknownErr) || !errors.Is(err, ErrUnknownSchedule) {
+		t.Fatalf("err = %v, want UnknownScheduleError", err)
+	}
+	if !reflect.DeepEqual(unknownErr.Names, []string{"retro", "typo"}) {
+		t.Errorf("unknown = %v, want another guild's schedule and the typo", unknownErr.Names)
+	}
+
+	config, err = service.Get("guild-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !reflect.DeepEqual(config.EnabledSchedules, []string{"morning", "standup"}) {
+		t.Errorf("rejected update changed config: %v", config.EnabledSchedules)
+	}
+
+	if _, err := service.SetSchedules("guild-1", nil); err != nil {
+		t.Errorf("empty list (all schedules) should be accepted: %v", err)
+	}
+}
+
+func TestScheduleNames(t *testing.T) {
+	service, _ := newTestGuildConfigService(t,
+		repository.Schedule{ID: "morning", Name: "morning", CronExpr: "0 8 * * *"},
+		repository.Schedule{ID: "evening", Name: "evening", CronExpr: "0 17 * * *"},
+		repository.Schedule{ID: "g2-retro", Name: "retro", CronExpr: "0 16 * * 5", GuildID: "guild-2"},
+	)
+
+	names, err := service.ScheduleNames("guild-2")
+	if err != nil {
+		t.Fatalf("ScheduleNames: %v", err)
+	}
+	if want := []string{"evening", "morning", "retro"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestGuildConfigConcurrentUpdates(t *testing.T) {
+	service, _ := newTestGuildConfigService(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := service.AddNewsChannel("guild-1", fmt.Sprintf("channel-%d", i)); err != nil {
+				t.Errorf("AddNewsChannel: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	config, err := service.Get("guild-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(config.NewsChannelIDs) != 20 {
+		t.Errorf("got %d news channels, want 20 (lost updates)", len(config.NewsChannelIDs))
+	}
+}
+
+func TestCommandChannelAllowed(t *testing.T) {
+	service, _ := newTestGuildConfigService(t)
+
+	if !service.CommandChannelAllowed("guild-1", "c1", "🕹️┃dev-talk") {
+		t.Error("default command channel name should be allowed")
+	}
+	if service.CommandChannelAllowed("guild-1", "c1", "random") {
+		t.Error("other channels should not be allowed by default")
+	}
+	if service.CommandChannelAllowed("", "c1", "🕹️┃dev-talk") {
+		t.Error("commands outside a guild should not be allowed")
+	}
+
+	if _, err := service.AddCommandChannel("guild-1", "c2"); err != nil {
+		t.Fatalf("AddCommandChannel: %v", err)
+	}
+	if !service.CommandChannelAllowed("guild-1", "c2", "random") {
+		t.Error("configured channel should be allowed")
+	}
+	if service.CommandChannelAllowed("guild-1", "c1", "🕹️┃dev-talk") {
+		t.Error("configured channels replace the default names")
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"discord-ai-tech-news/internal/response"
+)
+
+const (
+	// gatewayLatencyLimit: heartbeat yang lebih lambat dari ini dianggap koneksi bermasalah
+	gatewayLatencyLimit = 5 * time.Second
+	// gatewayAckTimeout: Discord mengirim heartbeat ACK kira-kira setiap 41 detik;
+	// ACK yang lebih tua dari ini menandakan koneksi zombie
+	gatewayAckTimeout = 2 * time.Minute
+	// newsFailureThreshold: sumber berita baru dianggap tidak sehat setelah fetch gagal
+	// sebanyak ini berturut-turut, agar satu error sesaat tidak membuat /health 503
+	newsFailureThreshold = 3
+
+	ServiceHealthy   = "healthy"
+	ServiceUnhealthy = "unhealthy"
+	StatusDegraded   = "degraded"
+)
+
+// GatewayStatus melaporkan status koneksi gateway Discord (diimplementasikan bot.DiscordBot)
+type GatewayStatus interface {
+	GatewayReady() bool
+	Heartbeat() (latency time.Duration, lastAck time.Time)
+}
+
+// FetchReporter melaporkan hasil pengambilan berita terakhir (diimplementasikan ExternalNewsService)
+type FetchReporter interface {
+	LastFetch() FetchStatus
+}
+
+// SchedulerState melaporkan apakah scheduler berjalan (diimplementasikan CronService)
+type SchedulerState interface {
+	Running() bool
+}
+
+// StoragePinger memeriksa database lokal (diimplementasikan repository.BoltPinger)
+type StoragePinger interface {
+	Ping() error
+}
+
+// HealthCheck adalah hasil satu pemeriksaan readiness
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Detail  string `json:"detail"`
+}
+
+// HealthService memeriksa komponen bot: koneksi gateway Discord, sumber berita,
+// scheduler dan storage. Dipakai oleh /health dan /readyz.
+type HealthService struct {
+	gateway   GatewayStatus
+	scheduler SchedulerState
+	news      FetchReporter
+	storage   StoragePinger
+}
+
+func NewHealthService(gateway GatewayStatus, scheduler SchedulerState, news FetchReporter, storage StoragePinger) *HealthService {
+	return &HealthService{
+		gateway:   gateway,
+		scheduler: scheduler,
+		news:      news,
+		storage:   storage,
+	}
+}
+
+// Health memeriksa semua komponen. Status keseluruhan "degraded" jika ada satu
+// komponen yang tidak sehat; JSONHandler.HealthResponse membalasnya dengan 503.
+func (h *HealthService) Health() *response.HealthResponse {
+	services := map[string]response.ServiceInfo{
+		"discord_gateway": h.checkGateway(),
+		"news_source":     h.checkNewsSource(),
+		"scheduler":       h.checkScheduler(),
+		"storage":         h.checkStorage(),
+	}
+
+	status := ServiceHealthy
+	for _, service := range services {
+		if service.Status != ServiceHealthy {
+			status = StatusDegraded
+		}
+	}
+
+	return &response.HealthResponse{
+		Status:    status,
+		Timestamp: time.Now(),
+		Services:  services,
+	}
+}
+
+// Readiness memakai pemeriksaan yang sama dengan Health dalam format /readyz;
+// ready bernilai false jika ada yang gagal
+func (h *HealthService) Readiness() (checks []HealthCheck, ready bool) {
+	health := h.Health()
+
+	for _, name := range []string{"discord_gateway", "scheduler", "news_source", "storage"} {
+		service := health.Services[name]
+		detail := service.Error
+		if detail == "" {
+			detail = service.Status
+		}
+		checks = append(checks, HealthCheck{
+			Name:    name,
+			Healthy: service.Status == ServiceHealthy,
+			Detail:  detail,
+		})
+	}
+
+	return checks, health.Status == ServiceHealthy
+}
+
+func (h *HealthService) checkGateway() response.ServiceInfo {
+	now := time.Now()
+	info := response.ServiceInfo{Status: ServiceHealthy, LastChecked: now.Format(time.RFC3339)}
+
+	if !h.gateway.GatewayReady() {
+		info.Status = ServiceUnhealthy
+		info.Error = "gateway disconnected"
+		return info
+	}
+
+	latency, lastAck := h.gateway.Heartbeat()
+	if latency >= 0 {
+		info.ResponseTime = latency.Round(time.Millisecond).String()
+	}
+
+	switch {
+	case !lastAck.IsZero() && now.Sub(lastAck) > gatewayAckTimeout:
+		info.Status = ServiceUnhealthy
+		info.Error = fmt.Sprintf("no heartbeat ACK since %s", lastAck.Format(time.RFC3339))
+	case latency > gatewayLatencyLimit:
+		info.Status = ServiceUnhealthy
+		info.Error = fmt.Sprintf("heartbeat latency %s exceeds %s", latency.Round(time.Millisecond), gatewayLatencyLimit)
+	}
+	return info
+}
+
+// checkNewsSource gagal jika newsFailureThreshold fetch terakhir gagal berturut-turut;
+// belum pernah fetch dianggap sehat. LastChecked berisi waktu fetch sukses terakhir.
+func (h *HealthService) checkNewsSource() response.ServiceInfo {
+	status := h.news.LastFetch()
+	info := response.ServiceInfo{Status: ServiceHealthy}
+
+	if !status.LastSuccess.IsZero() {
+		info.LastChecked = status.LastSuccess.Format(time.RFC3339)
+	}
+	if status.Failures >= newsFailureThreshold {
+		info.Status = ServiceUnhealthy
+		info.Error = fmt.Sprintf("%d consecutive fetches failed, last at %s: %s", status.Failures, status.At.Format(time.RFC3339), status.Error)
+	}
+	return info
+}
+
+func (h *HealthService) checkScheduler() response.ServiceInfo {
+	info := response.ServiceInfo{Status: ServiceHealthy, LastChecked: time.Now().Format(time.RFC3339)}
+	if !h.scheduler.Running() {
+		info.Status = ServiceUnhealthy
+		info.Error = "scheduler stopped"
+	}
+	return info
+}
+
+func (h *HealthService) checkStorage() response.ServiceInfo {
+	start := time.Now()
+	err := h.storage.Ping()
+
+	info := response.ServiceInfo{
+		Status:       ServiceHealthy,
+		LastChecked:  start.Format(time.RFC3339),
+		ResponseTime: time.Since(start).Round(time.Microsecond).String(),
+	}
+	if err != nil {
+		info.Status = ServiceUnhealthy
+		info.Error = err.Error()
+	}
+	return info
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewsSourceHealthToleratesTransientFailures(t *testing.T) {
+	news := NewExternalNewsService(nil)
+	health := NewHealthService(nil, nil, news, nil)
+
+	if info := health.checkNewsSource(); info.Status != ServiceHealthy {
+		t.Fatalf("no fetch yet: status = %s, want healthy", info.Status)
+	}
+
+	news.recordFetch(nil)
+	for i := 1; i < newsFailureThreshold; i++ {
+		news.recordFetch(errors.New("upstream timeout"))
+		if info := health.checkNewsSource(); info.Status != ServiceHealthy {
+			t.Fatalf("after %d failures: status = %s (%s), want healthy", i, info.Status, info.Error)
+		}
+	}
+
+	news.recordFetch(errors.New("upstream timeout"))
+	info := health.checkNewsSource()
+	if info.Status != ServiceUnhealthy {
+		t.Fatalf("after %d failures: status = %s, want unhealthy", newsFailureThreshold, info.Status)
+	}
+	if info.LastChecked == "" {
+		t.Error("last successful fetch should still be reported")
+	}
+
+	news.recordFetch(nil)
+	if info := health.checkNewsSource(); info.Status != ServiceHealthy {
+		t.Errorf("after a success: status = %s, want healthy", info.Status)
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Strategi keepalive untuk host gratis yang menidurkan service tanpa traffic masuk
+const (
+	KeepaliveOff  = "off"  // tidak ada ping (default)
+	KeepaliveSelf = "self" // ping /livez milik sendiri lewat URL publik (SERVER_URL)
+	KeepaliveURL  = "url"  // ping URL eksternal, misal monitor uptime
+)
+
+// KeepaliveOptions mengatur strategi keepalive
+type KeepaliveOptions struct {
+	Mode     string
+	URL      string // tujuan ping; untuk mode self ini adalah base URL server
+	Interval time.Duration
+}
+
+// Keepalive mengirim GET berkala ke URL tujuan. Berjalan di goroutine sendiri,
+// bukan di scheduler, agar tetap jalan di replica yang bukan leader. Hanya
+// perubahan status (gagal/pulih) yang di-log supaya log tidak banjir.
+type Keepalive struct {
+	target   string
+	interval time.Duration
+	client   *http.Client
+	stop     chan struct{}
+}
+
+// NewKeepalive mengembalikan nil jika mode off
+func NewKeepalive(options KeepaliveOptions) (*Keepalive, error) {
+	target := options.URL
+	switch options.Mode {
+	case "", KeepaliveOff:
+		return nil, nil
+	case KeepaliveSelf:
+		target = strings.TrimRight(options.URL, "/") + "/livez"
+	case KeepaliveURL:
+		if target == "" {
+			return nil, fmt.Errorf("keepalive mode %q requires a URL", options.Mode)
+		}
+	default:
+		return nil, fmt.Errorf("unknown keepalive mode %q (expected %s, %s or %s)", options.Mode, KeepaliveOff, KeepaliveSelf, KeepaliveURL)
+	}
+
+	return &Keepalive{
+		target:   target,
+		interval: options.Interval,
+		client:   &http.Client{Timeout: 30 * time.Second},
+		stop:     make(chan struct{}),
+	}, nil
+}
+
+func (k *Keepalive) Start() {
+	log.Printf("🔄 Keepalive: GET %s every %s", k.target, k.interval)
+
+	go func() {
+		ticker := time.NewTicker(k.interval)
+		defer ticker.Stop()
+
+		healthy := true
+		for {
+			select {
+			case <-k.stop:
+				return
+			case <-ticker.C:
+			}
+
+			err := k.ping()
+			switch {
+			case err != nil && healthy:
+				log.Printf("⚠️ [KEEPALIVE] Ping to %s failed: %v", k.target, err)
+			case err == nil && !healthy:
+				log.Printf("✅ [KEEPALIVE] Ping to %s recovered", k.target)
+			}
+			healthy = err == nil
+		}
+	}()
+}
+
+func (k *Keepalive) Stop() {
+	close(k.stop)
+}
+
+func (k *Keepalive) ping() error {
+	resp, err := k.client.Get(k.target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewKeepaliveModes(t *testing.T) {
+	for _, mode := range []string{"", KeepaliveOff} {
+		if keepalive, err := NewKeepalive(KeepaliveOptions{Mode: mode, URL: "https://bot.example.com"}); keepalive != nil || err != nil {
+			t.Errorf("mode %q = %v, %v; want nil, nil", mode, keepalive, err)
+		}
+	}
+
+	keepalive, err := NewKeepalive(KeepaliveOptions{Mode: KeepaliveSelf, URL: "https://bot.example.com/", Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("self mode: %v", err)
+	}
+	if keepalive.target != "https://bot.example.com/livez" {
+		t.Errorf("self target = %s, want the server's /livez", keepalive.target)
+	}
+
+	if _, err := NewKeepalive(KeepaliveOptions{Mode: KeepaliveURL}); err == nil {
+		t.Error("url mode without a URL should fail")
+	}
+	if _, err := NewKeepalive(KeepaliveOptions{Mode: "sometimes", URL: "https://bot.example.com"}); err == nil {
+		t.Error("unknown mode should fail")
+	}
+}
+
+func TestKeepalivePingsUntilStopped(t *testing.T) {
+	// Dua ping pertama gagal; loop harus tetap jalan dan pulih setelahnya
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/livez" {
+			t.Errorf("keepalive pinged %s, want /livez", r.URL.Path)
+		}
+		if hits.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	keepalive, err := NewKeepalive(KeepaliveOptions{Mode: KeepaliveSelf, URL: server.URL, Interval: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewKeepalive: %v", err)
+	}
+	keepalive.Start()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for hits.Load() < 4 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	keepalive.Stop()
+	if got := hits.Load(); got < 4 {
+		t.Fatalf("keepalive pinged %d times, want it to keep pinging after failures", got)
+	}
+
+	// Ping yang sedang berjalan saat Stop boleh selesai, tapi setelah itu tidak ada ping lagi
+	time.Sleep(30 * time.Millisecond)
+	stopped := hits.Load()
+	time.Sleep(100 * time.Millisecond)
+	if got := hits.Load(); got != stopped {
+		t.Errorf("keepalive pinged %d more times after Stop", got-stopped)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"errors"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+// DescribeNewsError memetakan error dari sumber berita ke kode error dan pesan
+// yang ramah untuk pengguna Discord
+func DescribeNewsError(err error) (code string, message string) {
+	switch {
+	case errors.Is(err, repository.ErrRateLimited):
+		return "RATE_LIMITED", "Batas request ke sumber berita sudah tercapai, coba lagi nanti"
+	case errors.Is(err, repository.ErrAuthFailed):
+		return "AUTH_FAILED", "Autentikasi ke sumber berita gagal, hubungi admin bot"
+	case errors.Is(err, repository.ErrUpstreamDown):
+		return "UPSTREAM_DOWN", "Sumber berita sedang tidak dapat dihubungi"
+	case errors.Is(err, repository.ErrDecodeFailed):
+		return "DECODE_FAILED", "Sumber berita mengirim data yang tidak valid"
+	default:
+		return "NEWS_FETCH_ERROR", "Terjadi kesalahan saat mengambil berita"
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+func TestDescribeNewsError(t *testing.T) {
+	sourceErr := func(kind error) error {
+		return fmt.Errorf("failed to fetch news: %w", &repository.SourceError{Source: "newsapi", Kind: kind})
+	}
+
+	tests := []struct {
+		err         error
+		wantCode    string
+		wantMessage string
+	}{
+		{sourceErr(repository.ErrRateLimited), "RATE_LIMITED", "Batas request ke sumber berita sudah tercapai, coba lagi nanti"},
+		{sourceErr(repository.ErrAuthFailed), "AUTH_FAILED", "Autentikasi ke sumber berita gagal, hubungi admin bot"},
+		{sourceErr(repository.ErrUpstreamDown), "UPSTREAM_DOWN", "Sumber berita sedang tidak dapat dihubungi"},
+		{sourceErr(repository.ErrDecodeFailed), "DECODE_FAILED", "Sumber berita mengirim data yang tidak valid"},
+		// Aggregate menggabungkan error semua sumber dengan errors.Join
+		{errors.Join(sourceErr(repository.ErrUpstreamDown), sourceErr(repository.ErrRateLimited)), "RATE_LIMITED", "Batas request ke sumber berita sudah tercapai, coba lagi nanti"},
+		{errors.New("boom"), "NEWS_FETCH_ERROR", "Terjadi kesalahan saat mengambil berita"},
+	}
+	for _, tt := range tests {
+		code, message := DescribeNewsError(tt.err)
+		if code != tt.wantCode || message != tt.wantMessage {
+			t.Errorf("DescribeNewsError(%v) = %s %q, want %s %q", tt.err, code, message, tt.wantCode, tt.wantMessage)
+		}
+	}
+}
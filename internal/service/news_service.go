@@ -6,25 +6,50 @@ import (
 	"log"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"discord-ai-tech-news/internal/repository"
 )
 
+const (
+	// maxDiscordArticles adalah jumlah artikel yang ditampilkan dalam satu pesan teks Discord
+	maxDiscordArticles = 3
+	// maxTechNews adalah jumlah maksimal berita yang dikembalikan FetchTechNews
+	maxTechNews = 20
+)
+
 type NewsResponse struct {
 	News []repository.News `json:"news"`
+	// CacheHit bernilai true jika hasil dilayani dari cache tanpa memanggil sumber berita
+	CacheHit     bool          `json:"cacheHit"`
+	ResponseTime time.Duration `json:"responseTime"`
 }
 
 type NewsService interface {
-	FetchTechNews(ctx context.Context) (*NewsResponse, error)
-	SearchNews(ctx context.Context, keyword string) ([]repository.News, error) // ← ADD THIS
+	// FetchTechNews mengambil berita terbaru; opts.Keyword diabaikan
+	FetchTechNews(ctx context.Context, opts repository.SearchOptions) (*NewsResponse, error)
+	SearchNews(ctx context.Context, opts repository.SearchOptions) (*NewsResponse, error) // ← ADD THIS
 	ValidateNewsSource(source string) bool
 	FormatNewsForDiscord(news []repository.News) string
 	TimeAgo(t time.Time) string // ← ADD THIS for time formatting
 }
 
+// FetchStatus adalah hasil pengambilan berita terakhir dari sumber eksternal,
+// dipakai oleh readiness check
+type FetchStatus struct {
+	At          time.Time // waktu fetch terakhir; nol jika belum pernah fetch
+	Success     bool
+	Error       string
+	LastSuccess time.Time
+	Failures    int // jumlah fetch gagal berturut-turut sejak sukses terakhir
+}
+
 type ExternalNewsService struct {
 	repository repository.NewsRepository
+
+	mu        sync.Mutex
+	lastFetch FetchStatus
 }
 
 func NewExternalNewsService(repo repository.NewsRepository) *ExternalNewsService {
@@ -33,40 +58,101 @@ func NewExternalNewsService(repo repository.NewsRepository) *ExternalNewsService
 	}
 }
 
-func (s *ExternalNewsService) FetchTechNews(ctx context.Context) (*NewsResponse, error) {
-	// Ambil berita teknologi dari 24 jam terakhir
-	since := time.Now().Add(-24 * time.Hour)
-	news, err := s.repository.GetLatestNewsSince(since)
+func (s *ExternalNewsService) FetchTechNews(ctx context.Context, opts repository.SearchOptions) (*NewsResponse, error) {
+	// Ambil berita teknologi dari 24 jam terakhir, kecuali diminta lewat --since
+	start := time.Now()
+	since := opts.Since
+	if since.IsZero() {
+		since = start.Add(-24 * time.Hour)
+	}
+	news, cacheHit, err := s.latestNews(since)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch news: %w", err)
 	}
 
-	// Filter tech-related news
-	techNews := s.filterTechNews(news)
-
-	// Limit ke 5 berita terbaru untuk performa
-	if len(techNews) > 5 {
-		techNews = techNews[:5]
+	// Filter tech-related news, lalu gabungkan berita yang sama dari beberapa sumber
+	techNews := dedupNews(s.filterTechNews(news))
+	if opts.Source != "" {
+		techNews = filterSource(techNews, opts.Source)
 	}
 
-	return &NewsResponse{News: techNews}, nil
+	// Limit jumlah berita; ditampilkan per halaman di Discord
+	techNews = applySearchOptions(techNews, opts, maxTechNews)
+
+	return &NewsResponse{News: techNews, CacheHit: cacheHit, ResponseTime: time.Since(start)}, nil
 }
 
 // Add SearchNews method
-func (s *ExternalNewsService) SearchNews(ctx context.Context, keyword string) ([]repository.News, error) {
-	log.Printf("🔍 DEBUG: Service searching for: %s", keyword)
+func (s *ExternalNewsService) SearchNews(ctx context.Context, opts repository.SearchOptions) (*NewsResponse, error) {
+	log.Printf("🔍 DEBUG: Service searching for: %s", opts.Keyword)
 
 	// Call repository search
-	results, err := s.repository.SearchNews(keyword)
+	start := time.Now()
+	results, cacheHit, err := s.searchNews(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search news: %w", err)
 	}
 
 	// Filter and validate results
-	validResults := s.filterSearchResults(results, keyword)
+	validResults := dedupNews(s.filterSearchResults(results, opts.Keyword))
+	validResults = applySearchOptions(validResults, opts, 0)
+
+	log.Printf("✅ DEBUG: Search returned %d valid results (cache hit: %t)", len(validResults), cacheHit)
+	return &NewsResponse{News: validResults, CacheHit: cacheHit, ResponseTime: time.Since(start)}, nil
+}
 
-	log.Printf("✅ DEBUG: Search returned %d valid results", len(validResults))
-	return validResults, nil
+// latestNews dan searchNews memakai varian *Cached jika repository dibungkus
+// CachedRepository. Hasil dari cache tidak dicatat sebagai fetch, jadi health
+// check tetap mencerminkan kondisi sumber berita yang sebenarnya.
+func (s *ExternalNewsService) latestNews(since time.Time) ([]repository.News, bool, error) {
+	if cached, ok := s.repository.(repository.CacheReporter); ok {
+		news, cacheHit, err := cached.GetLatestNewsSinceCached(since)
+		if !cacheHit {
+			s.recordFetch(err)
+		}
+		return news, cacheHit, err
+	}
+
+	news, err := s.repository.GetLatestNewsSince(since)
+	s.recordFetch(err)
+	return news, false, err
+}
+
+func (s *ExternalNewsService) searchNews(opts repository.SearchOptions) ([]repository.News, bool, error) {
+	if cached, ok := s.repository.(repository.CacheReporter); ok {
+		news, cacheHit, err := cached.SearchNewsCached(opts)
+		if !cacheHit {
+			s.recordFetch(err)
+		}
+		return news, cacheHit, err
+	}
+
+	news, err := s.repository.SearchNews(opts)
+	s.recordFetch(err)
+	return news, false, err
+}
+
+// LastFetch mengembalikan hasil pengambilan berita terakhir
+func (s *ExternalNewsService) LastFetch() FetchStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastFetch
+}
+
+func (s *ExternalNewsService) recordFetch(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastFetch.At = time.Now()
+	s.lastFetch.Success = err == nil
+	s.lastFetch.Error = ""
+	if err != nil {
+		s.lastFetch.Error = err.Error()
+		s.lastFetch.Failures++
+		return
+	}
+	s.lastFetch.LastSuccess = s.lastFetch.At
+	s.lastFetch.Failures = 0
 }
 
 func (s *ExternalNewsService) ValidateNewsSource(source string) bool {
@@ -93,7 +179,7 @@ func (s *ExternalNewsService) FormatNewsForDiscord(news []repository.News) strin
 	result.WriteString("📰 **Tech News Update - Berita Teknologi Terbaru**\n\n")
 
 	for i, article := range news {
-		if i >= 3 { // Limit ke 3 berita untuk Discord
+		if i >= maxDiscordArticles { // Limit ke 3 berita untuk Discord
 			break
 		}
 
@@ -109,7 +195,14 @@ func (s *ExternalNewsService) FormatNewsForDiscord(news []repository.News) strin
 			result.WriteString(fmt.Sprintf("📝 %s\n", description))
 		}
 		result.WriteString(fmt.Sprintf("🔗 [Baca Selengkapnya](%s)\n", article.URL))
-		result.WriteString(fmt.Sprintf("📅 %s • 📰 %s\n\n", timeAgo, article.Source))
+		result.WriteString(fmt.Sprintf("📅 %s • 📰 %s", timeAgo, article.Source))
+		if article.Score > 0 {
+			result.WriteString(fmt.Sprintf(" • ⭐ %d points • 💬 %d", article.Score, article.Comments))
+		}
+		if len(article.RelatedSources) > 0 {
+			result.WriteString(fmt.Sprintf("\n🗞️ Juga diliput oleh: %s", strings.Join(article.RelatedSources, ", ")))
+		}
+		result.WriteString("\n\n")
 	}
 
 	result.WriteString("---\n💡 *Ketik `help` untuk melihat command lainnya*")
@@ -151,6 +244,10 @@ func (s *ExternalNewsService) containsTechKeywords(article repository.News, keyw
 
 // Add TimeAgo helper method
 func (s *ExternalNewsService) TimeAgo(t time.Time) string {
+	if t.IsZero() {
+		return "Tanggal tidak diketahui"
+	}
+
 	now := time.Now()
 	diff := now.Sub(t)
 
@@ -188,10 +285,70 @@ func (s *ExternalNewsService) filterSearchResults(results []repository.News, key
 		}
 	}
 
-	// Sort by published date (newest first)
-	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].PublishedAt.After(filtered[j].PublishedAt)
-	})
+	// Urutan dari repository dipertahankan (sudah di-rank berdasarkan bobot sumber)
+	return filtered
+}
 
+// applySearchOptions menyaring hasil gabungan semua sumber dengan filter dari opts,
+// karena tidak semua sumber mendukung setiap filter, lalu mengurutkan dan
+// memotongnya. --source sudah diarahkan oleh repository sehingga tidak disaring
+// di sini. Artikel tanpa info bahasa tetap disertakan. Limit 0 memakai
+// defaultLimit; defaultLimit 0 berarti tanpa batas.
+func applySearchOptions(news []repository.News, opts repository.SearchOptions, defaultLimit int) []repository.News {
+	var filtered []repository.News
+	for _, article := range news {
+		if article.PublishedAt.Before(opts.Since) {
+			continue
+		}
+		if opts.Language != "" && article.Language != "" && article.Language != opts.Language {
+			continue
+		}
+		filtered = append(filtered, article)
+	}
+
+	switch opts.Sort {
+	case repository.SortPopularity:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			if filtered[i].Score != filtered[j].Score {
+				return filtered[i].Score > filtered[j].Score
+			}
+			return len(filtered[i].RelatedSources) > len(filtered[j].RelatedSources)
+		})
+	case repository.SortNewest:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].PublishedAt.After(filtered[j].PublishedAt)
+		})
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
 	return filtered
 }
+
+// filterSource menyaring berita terbaru berdasarkan --source. Berita terbaru
+// selalu diambil dari semua sumber, jadi penyaringan dilakukan per artikel.
+func filterSource(news []repository.News, source string) []repository.News {
+	var filtered []repository.News
+	for _, article := range news {
+		if matchesSource(article, source) {
+			filtered = append(filtered, article)
+		}
+	}
+	return filtered
+}
+
+// matchesSource mencocokkan sumber artikel dengan --source. Sumber lain yang
+// meliput artikel yang sama juga dihitung.
+func matchesSource(article repository.News, source string) bool {
+	for _, name := range append([]string{article.Source}, article.RelatedSources...) {
+		if repository.SourceMatches(name, source) {
+			return true
+		}
+	}
+	return false
+}
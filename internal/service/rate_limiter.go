@@ -0,0 +1,137 @@
+package service
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Scope rate limit command Discord dan HTTP
+const (
+	RateScopeUser    = "user"
+	RateScopeChannel = "channel"
+	RateScopeGuild   = "guild"
+	RateScopeClient  = "client" // IP client HTTP
+)
+
+// RateLimit mengizinkan Limit permintaan per Period; Limit <= 0 menonaktifkan scope tersebut
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
+// RateKey adalah satu bucket yang harus punya token, misal user ID pada scope user
+type RateKey struct {
+	Scope string
+	ID    string
+}
+
+// RateDecision adalah hasil RateLimiter.Allow
+type RateDecision struct {
+	Allowed bool
+	// Scope dan RetryAfter diisi jika ditolak: bucket yang habis dan kapan token berikutnya tersedia
+	Scope      string
+	RetryAfter time.Duration
+	// FirstDenial bernilai true untuk penolakan pertama sejak token terakhir diberikan,
+	// agar balasan cooldown tidak ikut di-spam
+	FirstDenial bool
+}
+
+type tokenBucket struct {
+	tokens   float64
+	updated  time.Time
+	notified bool
+}
+
+// RateLimiter adalah token bucket per scope dan ID. Bucket terisi penuh sebanyak
+// Limit token dan bertambah Limit/Period token per detik, jadi burst singkat tetap
+// dilayani selama rata-ratanya di bawah limit.
+type RateLimiter struct {
+	limits map[string]RateLimit
+
+	mu        sync.Mutex
+	buckets   map[RateKey]*tokenBucket
+	lastSweep time.Time
+}
+
+func NewRateLimiter(limits map[string]RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limits:    limits,
+		buckets:   make(map[RateKey]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow mengambil satu token dari setiap bucket keys. Token hanya diambil jika semua
+// bucket masih punya token, jadi user yang tertahan limit channel tidak ikut kehilangan
+// kuotanya sendiri. Key dengan ID kosong (misal guild di DM) dan scope tanpa limit dilewati.
+// RateLimiter nil selalu mengizinkan.
+func (l *RateLimiter) Allow(keys ...RateKey) RateDecision {
+	if l == nil {
+		return RateDecision{Allowed: true}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	var buckets []*tokenBucket
+	for _, key := range keys {
+		limit, ok := l.limits[key.Scope]
+		if !ok || limit.Limit <= 0 || key.ID == "" {
+			continue
+		}
+
+		bucket := l.refill(key, limit, now)
+		if bucket.tokens < 1 {
+			firstDenial := !bucket.notified
+			bucket.notified = true
+
+			perToken := limit.Period / time.Duration(limit.Limit)
+			return RateDecision{
+				Scope:       key.Scope,
+				RetryAfter:  time.Duration(math.Ceil((1 - bucket.tokens) * float64(perToken))),
+				FirstDenial: firstDenial,
+			}
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens--
+		bucket.notified = false
+	}
+	return RateDecision{Allowed: true}
+}
+
+// refill menambah token sesuai waktu sejak bucket terakhir dipakai; dipanggil dengan mu terkunci
+func (l *RateLimiter) refill(key RateKey, limit RateLimit, now time.Time) *tokenBucket {
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.Limit), updated: now}
+		l.buckets[key] = bucket
+		return bucket
+	}
+
+	rate := float64(limit.Limit) / limit.Period.Seconds()
+	bucket.tokens = math.Min(float64(limit.Limit), bucket.tokens+now.Sub(bucket.updated).Seconds()*rate)
+	bucket.updated = now
+	return bucket
+}
+
+// sweep membuang bucket yang sudah penuh kembali (tidak dipakai selama satu Period)
+// agar map tidak tumbuh terus; dijalankan paling sering sekali per menit
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.updated) >= l.limits[key.Scope].Period {
+			delete(l.buckets, key)
+		}
+	}
+}
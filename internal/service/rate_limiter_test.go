@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	limiter := NewRateLimiter(map[string]RateLimit{
+		RateScopeUser: {Limit: 2, Period: time.Minute},
+	})
+	key := RateKey{Scope: RateScopeUser, ID: "u1"}
+
+	for i := 0; i < 2; i++ {
+		if !limiter.Allow(key).Allowed {
+			t.Fatalf("request %d within the burst was denied", i)
+		}
+	}
+
+	denied := limiter.Allow(key)
+	if denied.Allowed || denied.Scope != RateScopeUser || !denied.FirstDenial {
+		t.Fatalf("third request = %+v, want the first denial on the user scope", denied)
+	}
+	if denied.RetryAfter <= 0 || denied.RetryAfter > 30*time.Second {
+		t.Errorf("RetryAfter = %s, want at most one token interval (30s)", denied.RetryAfter)
+	}
+	if again := limiter.Allow(key); again.FirstDenial {
+		t.Error("repeated denial should not be reported as the first")
+	}
+
+	// Mundurkan waktu bucket satu token interval untuk mensimulasikan refill
+	limiter.mu.Lock()
+	limiter.buckets[key].updated = limiter.buckets[key].updated.Add(-30 * time.Second)
+	limiter.mu.Unlock()
+	if !limiter.Allow(key).Allowed {
+		t.Error("request after one token interval was denied")
+	}
+}
+
+func TestRateLimiterTakesTokensOnlyWhenAllScopesAllow(t *testing.T) {
+	limiter := NewRateLimiter(map[string]RateLimit{
+		RateScopeUser:    {Limit: 5, Period: time.Minute},
+		RateScopeChannel: {Limit: 1, Period: time.Minute},
+		RateScopeGuild:   {}, // nonaktif
+	})
+	user := RateKey{Scope: RateScopeUser, ID: "u1"}
+	channel := RateKey{Scope: RateScopeChannel, ID: "c1"}
+	guild := RateKey{Scope: RateScopeGuild, ID: "g1"}
+
+	if !limiter.Allow(user, channel, guild).Allowed {
+		t.Fatal("first request was denied")
+	}
+	for i := 0; i < 3; i++ {
+		if decision := limiter.Allow(user, channel, guild); decision.Allowed || decision.Scope != RateScopeChannel {
+			t.Fatalf("request %d = %+v, want a channel denial", i, decision)
+		}
+	}
+
+	// Penolakan channel tidak mengurangi token user
+	limiter.mu.Lock()
+	tokens := limiter.buckets[user].tokens
+	limiter.mu.Unlock()
+	if tokens < 3.99 {
+		t.Errorf("user tokens = %.2f, want 4 after one allowed request", tokens)
+	}
+
+	// Guild kosong (DM) dan scope tanpa limit selalu lolos
+	if !limiter.Allow(RateKey{Scope: RateScopeChannel, ID: "c2"}, RateKey{Scope: RateScopeGuild}).Allowed {
+		t.Error("request in another channel was denied")
+	}
+	var disabled *RateLimiter
+	if !disabled.Allow(user).Allowed {
+		t.Error("nil limiter should allow everything")
+	}
+}
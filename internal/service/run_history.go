@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxRunHistory adalah jumlah maksimal RunRecord yang disimpan di memori
+const maxRunHistory = 100
+
+// RunRecord adalah hasil satu kali eksekusi jadwal berita otomatis
+type RunRecord struct {
+	ScheduleID     string        `json:"schedule_id"`
+	Name           string        `json:"name"`
+	Timezone       string        `json:"timezone"`
+	StartedAt      time.Time     `json:"started_at"`
+	Duration       time.Duration `json:"duration_ns"`
+	Success        bool          `json:"success"`
+	Error          string        `json:"error,omitempty"`
+	Channels       int           `json:"channels"`
+	ArticlesPosted int           `json:"articles_posted"`
+	CatchUp        bool          `json:"catch_up,omitempty"`
+}
+
+// Summary meringkas hasil run dalam satu baris, misal "✅ 5 artikel ke 2 channel (1.2s)"
+func (r RunRecord) Summary() string {
+	if !r.Success {
+		return "❌ " + r.Error
+	}
+	summary := fmt.Sprintf("✅ %d artikel ke %d channel (%s)", r.ArticlesPosted, r.Channels, r.Duration.Round(time.Millisecond))
+	if r.CatchUp {
+		summary += " • catch-up"
+	}
+	return summary
+}
+
+// RunHistory adalah ring buffer RunRecord; record tertua dibuang saat penuh
+type RunHistory struct {
+	mu      sync.Mutex
+	records []RunRecord
+	next    int
+	full    bool
+}
+
+// NewRunHistory membuat ring buffer berisi size record; size <= 0 dianggap 1
+func NewRunHistory(size int) *RunHistory {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &RunHistory{
+		records: make([]RunRecord, size),
+	}
+}
+
+func (h *RunHistory) Add(record RunRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// List mengembalikan record terbaru lebih dulu. scheduleID kosong berarti semua jadwal;
+// limit <= 0 berarti tanpa batas.
+func (h *RunHistory) List(scheduleID string, limit int) []RunRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	count := h.next
+	if h.full {
+		count = len(h.records)
+	}
+
+	var result []RunRecord
+	for i := 1; i <= count; i++ {
+		record := h.records[(h.next-i+len(h.records))%len(h.records)]
+		if scheduleID != "" && record.ScheduleID != scheduleID {
+			continue
+		}
+		result = append(result, record)
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+	return result
+}
+
+// Last mengembalikan record terakhir untuk jadwal di zona waktu tertentu
+func (h *RunHistory) Last(scheduleID, timezone string) *RunRecord {
+	for _, record := range h.List(scheduleID, 0) {
+		if record.Timezone == timezone {
+			return &record
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+)
+
+// runIDs mengembalikan Name setiap record sesuai urutan
+func runIDs(records []RunRecord) string {
+	var names []string
+	for _, record := range records {
+		names = append(names, record.Name)
+	}
+	return fmt.Sprint(names)
+}
+
+func TestRunHistoryWrapsAndListsNewestFirst(t *testing.T) {
+	history := NewRunHistory(3)
+	if got := history.List("", 0); len(got) != 0 {
+		t.Fatalf("empty history = %v", got)
+	}
+
+	for i := 1; i <= 2; i++ {
+		history.Add(RunRecord{ScheduleID: "morning", Name: fmt.Sprintf("run-%d", i)})
+	}
+	if got := runIDs(history.List("", 0)); got != "[run-2 run-1]" {
+		t.Errorf("before wraparound = %s", got)
+	}
+
+	for i := 3; i <= 5; i++ {
+		history.Add(RunRecord{ScheduleID: "morning", Name: fmt.Sprintf("run-%d", i)})
+	}
+	if got := runIDs(history.List("", 0)); got != "[run-5 run-4 run-3]" {
+		t.Errorf("after wraparound = %s, want the 3 newest", got)
+	}
+}
+
+func TestRunHistoryFilters(t *testing.T) {
+	history := NewRunHistory(10)
+	history.Add(RunRecord{ScheduleID: "morning", Name: "m1", Timezone: "Asia/Jakarta"})
+	history.Add(RunRecord{ScheduleID: "evening", Name: "e1", Timezone: "Asia/Jakarta"})
+	history.Add(RunRecord{ScheduleID: "morning", Name: "m2", Timezone: "Asia/Tokyo"})
+	history.Add(RunRecord{ScheduleID: "morning", Name: "m3", Timezone: "Asia/Jakarta"})
+
+	tests := []struct {
+		scheduleID string
+		limit      int
+		want       string
+	}{
+		{"", 0, "[m3 m2 e1 m1]"},
+		{"", 2, "[m3 m2]"},
+		{"morning", 0, "[m3 m2 m1]"},
+		{"morning", 1, "[m3]"},
+		{"evening", 5, "[e1]"},
+		{"unknown", 0, "[]"},
+	}
+	for _, tt := range tests {
+		if got := runIDs(history.List(tt.scheduleID, tt.limit)); got != tt.want {
+			t.Errorf("List(%q, %d) = %s, want %s", tt.scheduleID, tt.limit, got, tt.want)
+		}
+	}
+
+	if last := history.Last("morning", "Asia/Tokyo"); last == nil || last.Name != "m2" {
+		t.Errorf("Last(morning, Asia/Tokyo) = %+v, want m2", last)
+	}
+	if last := history.Last("morning", "Asia/Jakarta"); last == nil || last.Name != "m3" {
+		t.Errorf("Last(morning, Asia/Jakarta) = %+v, want m3", last)
+	}
+	if last := history.Last("evening", "Asia/Tokyo"); last != nil {
+		t.Errorf("Last(evening, Asia/Tokyo) = %+v, want nil", last)
+	}
+}
+
+func TestRunHistoryNonPositiveSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		history := NewRunHistory(size)
+		history.Add(RunRecord{Name: "a"})
+		history.Add(RunRecord{Name: "b"})
+		if got := runIDs(history.List("", 0)); got != "[b]" {
+			t.Errorf("NewRunHistory(%d) keeps %s, want [b]", size, got)
+		}
+	}
+}
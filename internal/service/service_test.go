@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// openTestDB membuka database bbolt baru di direktori sementara test
+func openTestDB(t *testing.T) *bolt.DB {
+	t.Helper()
+	db, err := repository.OpenBoltDB(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("OpenBoltDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestGuildConfigService membuat GuildConfigService dengan jadwal awal schedules
+func newTestGuildConfigService(t *testing.T, schedules ...repository.Schedule) (*GuildConfigService, *repository.BoltScheduleRepository) {
+	t.Helper()
+	db := openTestDB(t)
+
+	configRepo, err := repository.NewBoltGuildConfigRepository(db)
+	if err != nil {
+		t.Fatalf("NewBoltGuildConfigRepository: %v", err)
+	}
+	scheduleRepo, err := repository.NewBoltScheduleRepository(db, schedules)
+	if err != nil {
+		t.Fatalf("NewBoltScheduleRepository: %v", err)
+	}
+	return NewGuildConfigService(configRepo, scheduleRepo), scheduleRepo
+}
+
+// newTestCronService membuat CronService yang scheduler-nya belum dijalankan,
+// dengan jadwal awal schedules dan zona waktu default Asia/Jakarta
+func newTestCronService(t *testing.T, options CronOptions, schedules ...repository.Schedule) (*CronService, *repository.BoltScheduleRepository) {
+	t.Helper()
+	guildConfig, scheduleRepo := newTestGuildConfigService(t, schedules...)
+
+	history, err := repository.NewBoltHistoryRepository(openTestDB(t))
+	if err != nil {
+		t.Fatalf("NewBoltHistoryRepository: %v", err)
+	}
+	if options.Location == nil {
+		options.Location = mustLoadLocation(t, "Asia/Jakarta")
+	}
+
+	cs := NewCronService(nil, history, guildConfig, scheduleRepo, options)
+	t.Cleanup(func() { cs.scheduler.Shutdown() })
+	return cs, scheduleRepo
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%s): %v", name, err)
+	}
+	return location
+}
+
+// fakeBot mencatat pesan yang dikirim alih-alih memanggil Discord.
+// Channel default setiap guild bernama "<guildID>-news".
+type fakeBot struct {
+	mu     sync.Mutex
+	guilds []string
+	sent   map[string][]*response.DiscordMessage // channel ID -> pesan
+}
+
+func newFakeBot(guilds ...string) *fakeBot {
+	return &fakeBot{guilds: guilds, sent: make(map[string][]*response.DiscordMessage)}
+}
+
+func (b *fakeBot) GuildIDs() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.guilds...)
+}
+
+func (b *fakeBot) join(guildID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.guilds = append(b.guilds, guildID)
+}
+
+func (b *fakeBot) FindChannelID(guildID, channelName string) (string, error) {
+	if channelName != DefaultNewsChannelNames[0] {
+		return "", fmt.Errorf("channel %s not found", channelName)
+	}
+	return guildID + "-news", nil
+}
+
+// ChannelGuildID mengenali channel "<guildID>-<nama>" dari guild tempat bot bergabung
+func (b *fakeBot) ChannelGuildID(channelID string) (string, error) {
+	guildID := channelID[:max(strings.LastIndex(channelID, "-"), 0)]
+	for _, guild := range b.GuildIDs() {
+		if guild == guildID {
+			return guildID, nil
+		}
+	}
+	return "", fmt.Errorf("channel %s not found", channelID)
+}
+
+func (b *fakeBot) SendMessage(channelID string, message *response.DiscordMessage) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent[channelID] = append(b.sent[channelID], message)
+	return fmt.Sprintf("%s-msg-%d", channelID, len(b.sent[channelID])), nil
+}
+
+func (b *fakeBot) messages(channelID string) []*response.DiscordMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sent[channelID]
+}
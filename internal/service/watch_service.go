@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+)
+
+const (
+	// MaxWatchTerms adalah jumlah kata kunci maksimal per user
+	MaxWatchTerms      = 10
+	minWatchTermLength = 2
+	maxWatchTermLength = 50
+
+	// maxWatchDMsPerPoll dan watchDMInterval membatasi laju DM; user yang belum
+	// kebagian dikirimi pada polling berikutnya
+	maxWatchDMsPerPoll = 50
+	watchDMInterval    = time.Second
+	// watchDMBackoff: user yang DM-nya gagal (misal DM ditutup, error 50007) dilewati
+	// selama ini, berlipat dua setiap kegagalan berturut-turut sampai maxWatchDMBackoff
+	watchDMBackoff    = time.Hour
+	maxWatchDMBackoff = 24 * time.Hour
+	// watchSentRetention: catatan artikel yang sudah dikirim disimpan selama ini
+	watchSentRetention = 7 * 24 * time.Hour
+)
+
+// ErrInvalidWatchTerm dikembalikan jika kata kunci terlalu pendek atau terlalu panjang
+var ErrInvalidWatchTerm = errors.New("invalid watch term")
+
+// DirectMessenger mengirim DM ke user Discord (diimplementasikan bot.DiscordBot)
+type DirectMessenger interface {
+	SendDirectMessage(userID string, message *response.DiscordMessage) (string, error)
+}
+
+// rateScopeWatchSearch adalah scope token bucket untuk semua request sumber berita dari
+// polling watchlist: fetch berita terbaru maupun SearchNews
+const rateScopeWatchSearch = "watch-search"
+
+// watchLatest adalah entri giliran untuk fetch berita terbaru di lastSearched; kata
+// kunci yang sudah dinormalisasi tidak pernah kosong
+const watchLatest = ""
+
+// WatchService mengelola watchlist kata kunci per user dan mengirim DM berisi
+// artikel baru yang cocok. Poll dijalankan berkala oleh CronService.
+type WatchService struct {
+	repo        repository.WatchRepository
+	newsService NewsService
+	messenger   DirectMessenger
+	renderer    *response.EmbedRenderer
+	searches    *RateLimiter // nil berarti polling tidak memanggil sumber berita sama sekali
+
+	// Hanya diakses di dalam Poll (diserialisasi oleh polling)
+	polling      sync.Mutex
+	lastSearched map[string]time.Time // term (atau watchLatest) -> waktu request terakhir
+	dmFailures   map[string]dmFailure // user ID -> kegagalan DM berturut-turut
+}
+
+// dmFailure mencatat DM yang gagal agar user tersebut tidak dicoba ulang setiap polling
+type dmFailure struct {
+	count int
+	until time.Time
+}
+
+// NewWatchService membuat WatchService. searchLimit membatasi semua request sumber
+// berita dari polling (misal 20 per 24 jam), termasuk fetch berita terbaru, agar
+// polling tidak menghabiskan kuota NewsAPI; Limit 0 berarti polling tidak mengambil
+// berita sama sekali.
+func NewWatchService(repo repository.WatchRepository, newsService NewsService, searchLimit RateLimit) *WatchService {
+	w := &WatchService{
+		repo:         repo,
+		newsService:  newsService,
+		renderer:     response.NewEmbedRenderer(),
+		lastSearched: make(map[string]time.Time),
+		dmFailures:   make(map[string]dmFailure),
+	}
+	// Berbeda dengan rate limit command, Limit 0 di sini berarti tanpa request sama sekali
+	if searchLimit.Limit > 0 {
+		w.searches = NewRateLimiter(map[string]RateLimit{rateScopeWatchSearch: searchLimit})
+	}
+	return w
+}
+
+// Start memasang pengirim DM. Sama seperti CronService, bot baru tersedia setelah
+// usecase dibuat, jadi tidak bisa diberikan lewat constructor.
+func (w *WatchService) Start(messenger DirectMessenger) {
+	w.messenger = messenger
+}
+
+// Watch menambah kata kunci untuk user. Mengembalikan term yang sudah dinormalisasi
+// dan false jika term sudah ada di watchlist.
+func (w *WatchService) Watch(userID, term string) (string, bool, error) {
+	term = normalizeWatchTerm(term)
+	length := utf8.RuneCountInString(term)
+	if length < minWatchTermLength || length > maxWatchTermLength {
+		return term, false, fmt.Errorf("%w: must be %d-%d characters", ErrInvalidWatchTerm, minWatchTermLength, maxWatchTermLength)
+	}
+
+	added, err := w.repo.AddWatch(userID, term, MaxWatchTerms)
+	return term, added, err
+}
+
+// Unwatch menghapus kata kunci; false jika tidak ada di watchlist user
+func (w *WatchService) Unwatch(userID, term string) (string, bool, error) {
+	term = normalizeWatchTerm(term)
+	removed, err := w.repo.RemoveWatch(userID, term)
+	return term, removed, err
+}
+
+func (w *WatchService) List(userID string) ([]repository.WatchTerm, error) {
+	return w.repo.ListWatches(userID)
+}
+
+// Poll mengambil berita terbaru dan hasil pencarian kata kunci sesuai jatah, lalu
+// mengirim satu DM per user berisi artikel baru yang cocok. Polling yang masih
+// berjalan tidak ditumpuk.
+func (w *WatchService) Poll() {
+	if w.messenger == nil || !w.polling.TryLock() {
+		return
+	}
+	defer w.polling.Unlock()
+
+	watches, err := w.repo.AllWatches()
+	if err != nil {
+		log.Printf("⚠️ [WATCH] Failed to load watchlists: %v", err)
+		return
+	}
+	if len(watches) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	candidates := w.fetchCandidates(ctx, watches)
+	now := time.Now()
+
+	sent, attempts := 0, 0
+	for _, user := range watches {
+		if sent >= maxWatchDMsPerPoll {
+			log.Printf("⏳ [WATCH] DM limit of %d reached, remaining users are notified next poll", maxWatchDMsPerPoll)
+			break
+		}
+		if failure, ok := w.dmFailures[user.UserID]; ok && now.Before(failure.until) {
+			continue
+		}
+
+		matches := matchWatchTerms(user.Terms, candidates)
+		unsent, err := w.repo.FilterUnsent(user.UserID, matches)
+		if err != nil {
+			log.Printf("⚠️ [WATCH] Failed to check sent articles for user %s: %v", user.UserID, err)
+			continue
+		}
+		if len(unsent) == 0 {
+			continue
+		}
+
+		// Satu DM per user per polling; sisa artikel dikirim pada polling berikutnya
+		batch := unsent[:min(len(unsent), response.MaxEmbedArticles)]
+
+		if attempts > 0 {
+			time.Sleep(watchDMInterval)
+		}
+		attempts++
+
+		if _, err := w.messenger.SendDirectMessage(user.UserID, w.formatAlert(batch, matchedTerms(user.Terms, batch), len(unsent)-len(batch))); err != nil {
+			backoff := w.recordDMFailure(user.UserID, now)
+			log.Printf("❌ [WATCH] Failed to DM user %s, retrying in %s: %v", user.UserID, backoff, err)
+			continue
+		}
+		delete(w.dmFailures, user.UserID)
+		sent++
+
+		if err := w.repo.RecordSent(user.UserID, batch, now); err != nil {
+			log.Printf("⚠️ [WATCH] Failed to record sent articles for user %s: %v", user.UserID, err)
+		}
+	}
+
+	if sent > 0 {
+		log.Printf("🔔 [WATCH] Sent %d watchlist DMs", sent)
+	}
+
+	if _, err := w.repo.PruneSent(now.Add(-watchSentRetention)); err != nil {
+		log.Printf("⚠️ [WATCH] Failed to prune sent articles: %v", err)
+	}
+}
+
+// recordDMFailure mencatat DM yang gagal dan mengembalikan berapa lama user dilewati
+func (w *WatchService) recordDMFailure(userID string, now time.Time) time.Duration {
+	failure := w.dmFailures[userID]
+	failure.count++
+
+	backoff := maxWatchDMBackoff
+	if failure.count <= 5 {
+		backoff = min(watchDMBackoff<<(failure.count-1), maxWatchDMBackoff)
+	}
+	failure.until = now.Add(backoff)
+	w.dmFailures[userID] = failure
+	return backoff
+}
+
+// fetchCandidates menggabungkan berita terbaru dan hasil SearchNews, tanpa duplikat URL.
+// Setiap request memakai satu token searches; berita terbaru ikut bergiliran dengan
+// kata kunci, jadi polling setiap WATCH_INTERVAL tidak memanggil sumber berita di luar
+// jatah tersebut.
+func (w *WatchService) fetchCandidates(ctx context.Context, watches []repository.UserWatches) []repository.News {
+	var candidates []repository.News
+	seen := make(map[string]bool)
+	add := func(news []repository.News) {
+		for _, article := range news {
+			if article.URL == "" || seen[article.URL] {
+				continue
+			}
+			seen[article.URL] = true
+			candidates = append(candidates, article)
+		}
+	}
+
+	if w.searches == nil {
+		return candidates
+	}
+
+	for _, term := range w.searchOrder(watches) {
+		if !w.searches.Allow(RateKey{Scope: rateScopeWatchSearch, ID: "poll"}).Allowed {
+			break
+		}
+		w.lastSearched[term] = time.Now()
+
+		if term == watchLatest {
+			latest, err := w.newsService.FetchTechNews(ctx, repository.SearchOptions{})
+			if err != nil {
+				log.Printf("⚠️ [WATCH] Failed to fetch latest news: %v", err)
+				continue
+			}
+			add(latest.News)
+			continue
+		}
+
+		results, err := w.newsService.SearchNews(ctx, repository.SearchOptions{Keyword: term})
+		if err != nil {
+			log.Printf("⚠️ [WATCH] Failed to search %q: %v", term, err)
+			continue
+		}
+		add(results.News)
+	}
+
+	return candidates
+}
+
+// searchOrder mengembalikan watchLatest dan kata kunci unik, yang belum pernah atau
+// paling lama tidak diambil lebih dulu, agar jatah request terbagi rata. Berita
+// terbaru didahulukan saat semuanya belum pernah diambil.
+func (w *WatchService) searchOrder(watches []repository.UserWatches) []string {
+	terms := []string{watchLatest}
+	seen := map[string]bool{watchLatest: true}
+	for _, user := range watches {
+		for _, watch := range user.Terms {
+			if !seen[watch.Term] {
+				seen[watch.Term] = true
+				terms = append(terms, watch.Term)
+			}
+		}
+	}
+
+	// Buang catatan kata kunci yang sudah tidak dipantau siapa pun
+	for term := range w.lastSearched {
+		if !seen[term] {
+			delete(w.lastSearched, term)
+		}
+	}
+
+	sort.SliceStable(terms, func(i, j int) bool {
+		return w.lastSearched[terms[i]].Before(w.lastSearched[terms[j]])
+	})
+	return terms
+}
+
+func (w *WatchService) formatAlert(news []repository.News, terms []string, remaining int) *response.DiscordMessage {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = "`" + term + "`"
+	}
+
+	content := fmt.Sprintf("🔔 **Watchlist Alert**\nArtikel baru untuk %s", strings.Join(quoted, ", "))
+	if remaining > 0 {
+		content += fmt.Sprintf("\n➕ %d artikel lain menyusul di update berikutnya", remaining)
+	}
+	content += "\n💡 *Kelola dengan `/watchlist` dan `/unwatch`*"
+
+	return &response.DiscordMessage{
+		Content: content,
+		Embeds:  w.renderer.RenderItems(response.ConvertToNewsItems(news), response.EmbedColorSearch),
+	}
+}
+
+// matchWatchTerms mengembalikan artikel (terbaru lebih dulu) yang mengandung salah
+// satu kata kunci dan terbit setelah kata kunci itu ditambahkan
+func matchWatchTerms(watches []repository.WatchTerm, candidates []repository.News) []repository.News {
+	var matches []repository.News
+	for _, article := range candidates {
+		if len(matchedTerms(watches, []repository.News{article})) > 0 {
+			matches = append(matches, article)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].PublishedAt.After(matches[j].PublishedAt)
+	})
+	return matches
+}
+
+// matchedTerms mengembalikan kata kunci yang cocok dengan minimal satu artikel
+func matchedTerms(watches []repository.WatchTerm, news []repository.News) []string {
+	var terms []string
+	for _, watch := range watches {
+		for _, article := range news {
+			content := strings.ToLower(article.Title + " " + article.Description)
+			if !article.PublishedAt.Before(watch.CreatedAt) && containsWord(content, watch.Term) {
+				terms = append(terms, watch.Term)
+				break
+			}
+		}
+	}
+	return terms
+}
+
+// containsWord mencari term sebagai kata utuh, jadi "ai" cocok dengan "AI startup" dan
+// "open-ai" tetapi tidak dengan "said" atau "email". Batas kata hanya dicek di sisi
+// term yang berupa huruf/angka, agar term seperti "c++" atau ".net" tetap bisa cocok.
+func containsWord(content, term string) bool {
+	if term == "" {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(term)
+	last, _ := utf8.DecodeLastRuneInString(term)
+
+	for offset := 0; offset < len(content); {
+		i := strings.Index(content[offset:], term)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(term)
+
+		before, _ := utf8.DecodeLastRuneInString(content[:start])
+		after, _ := utf8.DecodeRuneInString(content[end:])
+		if !(isWordRune(first) && start > 0 && isWordRune(before)) &&
+			!(isWordRune(last) && end < len(content) && isWordRune(after)) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(content[start:])
+		offset = start + size
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// normalizeWatchTerm menyamakan huruf dan spasi agar "Kubernetes " dan "kubernetes" dianggap sama
+func normalizeWatchTerm(term string) string {
+	return strings.ToLower(strings.Join(strings.Fields(term), " "))
+}
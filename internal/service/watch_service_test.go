@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+)
+
+func TestContainsWord(t *testing.T) {
+	tests := []struct {
+		content, term string
+		want          bool
+	}{
+		{"new ai startup raises", "ai", true},
+		{"ai", "ai", true},
+		{"open-ai releases a model", "ai", true},
+		{"(ai) in 2025", "ai", true},
+		{"he said the email was fine", "ai", false},
+		{"said ai", "ai", true}, // kemunculan pertama di dalam kata, kedua utuh
+		{"kubernetes 1.30 released", "kubernetes", true},
+		{"rust-based tools", "rust", true},
+		{"trusty rusty", "rust", false},
+		{"machine learning at scale", "machine learning", true},
+		{"machine learnings", "machine learning", false},
+		{"modern c++ tips", "c++", true},
+		{"asp.net core", ".net", true},
+		{"", "ai", false},
+	}
+
+	for _, tt := range tests {
+		if got := containsWord(tt.content, tt.term); got != tt.want {
+			t.Errorf("containsWord(%q, %q) = %t, want %t", tt.content, tt.term, got, tt.want)
+		}
+	}
+}
+
+// fakeWatchNews melayani berita terbaru dan hasil pencarian tetap, dan menghitung
+// panggilan FetchTechNews dan SearchNews
+type fakeWatchNews struct {
+	NewsService
+	latest   []repository.News
+	mu       sync.Mutex
+	fetches  int
+	searches []string
+}
+
+func (f *fakeWatchNews) FetchTechNews(ctx context.Context, opts repository.SearchOptions) (*NewsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetches++
+	return &NewsResponse{News: f.latest}, nil
+}
+
+func (f *fakeWatchNews) SearchNews(ctx context.Context, opts repository.SearchOptions) (*NewsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.searches = append(f.searches, opts.Keyword)
+	return &NewsResponse{}, nil
+}
+
+// fakeMessenger menolak DM ke user di blocked, seperti user yang menutup DM (error 50007)
+type fakeMessenger struct {
+	blocked  map[string]bool
+	attempts map[string]int
+	sent     map[string]int
+}
+
+func (m *fakeMessenger) SendDirectMessage(userID string, message *response.DiscordMessage) (string, error) {
+	m.attempts[userID]++
+	if m.blocked[userID] {
+		return "", errors.New("HTTP 403 Forbidden, Cannot send messages to this user (50007)")
+	}
+	m.sent[userID]++
+	return "dm-" + userID, nil
+}
+
+func newTestWatchService(t *testing.T, news NewsService, searchLimit RateLimit) *WatchService {
+	t.Helper()
+	repo, err := repository.NewBoltWatchRepository(openTestDB(t))
+	if err != nil {
+		t.Fatalf("NewBoltWatchRepository: %v", err)
+	}
+	return NewWatchService(repo, news, searchLimit)
+}
+
+func TestWatchPollBacksOffFailedDMs(t *testing.T) {
+	news := &fakeWatchNews{latest: []repository.News{
+		{Title: "Kubernetes 1.30 released", URL: "https://example.com/k8s", PublishedAt: time.Now().Add(time.Hour)},
+	}}
+	watch := newTestWatchService(t, news, RateLimit{Limit: 10, Period: 24 * time.Hour})
+	messenger := &fakeMessenger{blocked: map[string]bool{"closed-dms": true}, attempts: map[string]int{}, sent: map[string]int{}}
+	watch.Start(messenger)
+
+	for _, userID := range []string{"closed-dms", "reader"} {
+		if _, _, err := watch.Watch(userID, "kubernetes"); err != nil {
+			t.Fatalf("Watch: %v", err)
+		}
+	}
+
+	watch.Poll()
+	if messenger.sent["reader"] != 1 || messenger.attempts["closed-dms"] != 1 {
+		t.Fatalf("first poll: sent %v, attempts %v", messenger.sent, messenger.attempts)
+	}
+	if failure := watch.dmFailures["closed-dms"]; failure.count != 1 || time.Until(failure.until) < 59*time.Minute {
+		t.Errorf("failed DM should back off for an hour, got %+v", failure)
+	}
+
+	// Polling berikutnya tidak mencoba ulang user yang DM-nya gagal
+	watch.Poll()
+	if messenger.attempts["closed-dms"] != 1 {
+		t.Errorf("user in backoff was retried: %d attempts", messenger.attempts["closed-dms"])
+	}
+
+	// Setelah backoff lewat, kegagalan berikutnya menggandakan jeda
+	failure := watch.dmFailures["closed-dms"]
+	failure.until = time.Now().Add(-time.Second)
+	watch.dmFailures["closed-dms"] = failure
+	watch.Poll()
+	if messenger.attempts["closed-dms"] != 2 {
+		t.Fatalf("user should be retried after the backoff, got %d attempts", messenger.attempts["closed-dms"])
+	}
+	if failure := watch.dmFailures["closed-dms"]; failure.count != 2 || time.Until(failure.until) < 119*time.Minute {
+		t.Errorf("second failure should back off for two hours, got %+v", failure)
+	}
+
+	// Artikel yang sudah terkirim tidak dikirim ulang
+	if messenger.sent["reader"] != 1 {
+		t.Errorf("reader got %d DMs, want 1", messenger.sent["reader"])
+	}
+}
+
+func TestWatchSearchBudgetRotatesTerms(t *testing.T) {
+	news := &fakeWatchNews{}
+	watch := newTestWatchService(t, news, RateLimit{Limit: 2, Period: 24 * time.Hour})
+	watch.Start(&fakeMessenger{attempts: map[string]int{}, sent: map[string]int{}})
+
+	for _, term := range []string{"kubernetes", "rust", "golang"} {
+		if _, _, err := watch.Watch("user-1", term); err != nil {
+			t.Fatalf("Watch: %v", err)
+		}
+	}
+
+	// Berita terbaru diambil lebih dulu dan ikut memakai jatah harian
+	watch.Poll()
+	watch.Poll()
+	if news.fetches != 1 || len(news.searches) != 1 {
+		t.Fatalf("fetches = %d, searches = %v, want the daily budget of 2 shared by both", news.fetches, news.searches)
+	}
+
+	// Token berikutnya dipakai untuk kata kunci yang belum pernah dicari, baru berita terbaru
+	watch.searches = NewRateLimiter(map[string]RateLimit{rateScopeWatchSearch: {Limit: 3, Period: 24 * time.Hour}})
+	watch.Poll()
+	if want := []string{"kubernetes", "rust", "golang"}; !slices.Equal(news.searches, want) || news.fetches != 2 {
+		t.Errorf("fetches = %d, searches = %v, want 2 and %v", news.fetches, news.searches, want)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		news := &fakeWatchNews{}
+		watch := newTestWatchService(t, news, RateLimit{})
+		watch.Start(&fakeMessenger{attempts: map[string]int{}, sent: map[string]int{}})
+		if _, _, err := watch.Watch("user-1", "kubernetes"); err != nil {
+			t.Fatalf("Watch: %v", err)
+		}
+		watch.Poll()
+		if news.fetches != 0 || len(news.searches) != 0 {
+			t.Errorf("fetches = %d, searches = %v, want no requests when the limit is 0", news.fetches, news.searches)
+		}
+	})
+}
+
+func TestWatchPollsStayWithinBudget(t *testing.T) {
+	news := &fakeWatchNews{}
+	watch := newTestWatchService(t, news, RateLimit{Limit: 5, Period: 24 * time.Hour})
+	watch.Start(&fakeMessenger{attempts: map[string]int{}, sent: map[string]int{}})
+	if _, _, err := watch.Watch("user-1", "kubernetes"); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	// Sehari penuh polling dengan WATCH_INTERVAL default 15 menit
+	for i := 0; i < 96; i++ {
+		watch.Poll()
+	}
+	if requests := news.fetches + len(news.searches); requests != 5 {
+		t.Errorf("polling made %d upstream requests (%d fetches, searches %v), want the budget of 5", requests, news.fetches, news.searches)
+	}
+}
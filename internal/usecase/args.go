@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+// Flag command news dan search, misal: search "large language models" --since 3d
+const (
+	FlagSince  = "since"
+	FlagSource = "source"
+	FlagLang   = "lang"
+	FlagLimit  = "limit"
+	FlagSort   = "sort"
+)
+
+const (
+	maxSearchLimit = 50
+	// maxSearchSince mengikuti batas riwayat NewsAPI paket gratis (satu bulan)
+	maxSearchSince = 30 * 24 * time.Hour
+)
+
+var (
+	// newsFlags adalah flag yang didukung command news dan search, sesuai urutan di help
+	newsFlags = []CommandFlag{
+		{Name: FlagSince, Description: "Hanya artikel sejak durasi/tanggal ini, misal: 12h, 3d, 2w, 2025-01-31"},
+		{Name: FlagSource, Description: "Hanya dari sumber ini, misal: wired, hackernews"},
+		{Name: FlagLang, Description: "Kode bahasa ISO 639-1, misal: en, id"},
+		{Name: FlagLimit, Description: fmt.Sprintf("Jumlah artikel maksimal (1-%d)", maxSearchLimit), Type: OptionInteger, MinValue: 1, MaxValue: maxSearchLimit},
+		{Name: FlagSort, Description: "Urutan hasil", Choices: []string{repository.SortRelevance, repository.SortPopularity, repository.SortNewest}},
+	}
+
+	sinceDurationPattern = regexp.MustCompile(`^(\d+)([mhdw])$`)
+	languagePattern      = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+// ArgError adalah kesalahan argumen dari user; pesannya ditampilkan apa adanya
+type ArgError struct {
+	Message string
+}
+
+func (e *ArgError) Error() string {
+	return e.Message
+}
+
+func argErrorf(format string, args ...any) error {
+	return &ArgError{Message: fmt.Sprintf(format, args...)}
+}
+
+// argToken adalah satu argumen hasil splitArgs. Token dari dalam tanda kutip
+// tidak pernah dianggap flag, jadi "--since" di dalam kutip tetap kata kunci.
+type argToken struct {
+	value  string
+	quoted bool
+}
+
+// parsedArgs adalah argumen posisi dan nilai flag --nama dari satu command
+type parsedArgs struct {
+	positional []string
+	flags      map[string]string
+}
+
+// closingQuotes memetakan tanda kutip pembuka ke penutupnya; kutip miring
+// muncul dari keyboard HP yang otomatis mengganti tanda kutip
+var closingQuotes = map[rune]rune{
+	'"': '"',
+	'“': '”',
+}
+
+// splitArgs memecah input berdasarkan spasi. Teks di dalam tanda kutip ganda
+// menjadi satu token, misal `"large language models" --since 3d` menjadi tiga token.
+func splitArgs(input string) ([]argToken, error) {
+	var (
+		tokens  []argToken
+		current strings.Builder
+		inToken bool
+		quoted  bool
+		closing rune
+	)
+
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, argToken{value: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		inToken, quoted = false, false
+	}
+
+	for _, r := range input {
+		switch {
+		case closing != 0:
+			if r == closing {
+				closing = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		case closingQuotes[r] != 0 && !inToken:
+			closing = closingQuotes[r]
+			inToken, quoted = true, true
+		case closingQuotes[r] != 0 && strings.HasSuffix(current.String(), "="):
+			// Nilai flag dalam kutip: --source="the verge"
+			closing = closingQuotes[r]
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if closing != 0 {
+		return nil, argErrorf("Tanda kutip belum ditutup. Contoh: `\"large language models\"`")
+	}
+	flush()
+	return tokens, nil
+}
+
+// parseArgs memisahkan argumen posisi dan flag. Flag ditulis `--nama nilai` atau
+// `--nama=nilai` dan harus ada di daftar allowed.
+func parseArgs(input string, allowed []CommandFlag) (parsedArgs, error) {
+	tokens, err := splitArgs(input)
+	if err != nil {
+		return parsedArgs{}, err
+	}
+
+	args := parsedArgs{flags: make(map[string]string)}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.quoted || !strings.HasPrefix(token.value, "--") {
+			args.positional = append(args.positional, token.value)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(token.value, "--"), "=")
+		name = strings.ToLower(name)
+		if !hasFlag(allowed, name) {
+			return parsedArgs{}, argErrorf("Flag `--%s` tidak dikenal. Flag yang tersedia: %s", name, flagList(allowed))
+		}
+		if _, exists := args.flags[name]; exists {
+			return parsedArgs{}, argErrorf("Flag `--%s` diisi lebih dari sekali", name)
+		}
+		if !hasValue {
+			if i+1 >= len(tokens) || (!tokens[i+1].quoted && strings.HasPrefix(tokens[i+1].value, "--")) {
+				return parsedArgs{}, argErrorf("Flag `--%s` butuh nilai, misal: `--%s %s`", name, name, flagExample(name))
+			}
+			i++
+			value = tokens[i].value
+		}
+		args.flags[name] = value
+	}
+
+	return args, nil
+}
+
+// parseSearchOptions membaca kata kunci dan flag news/search dari req. Option slash
+// command (req.Options) menimpa flag dengan nama yang sama di teks argumen.
+func parseSearchOptions(req CommandRequest, now time.Time) (repository.SearchOptions, error) {
+	args, err := parseArgs(req.Args, newsFlags)
+	if err != nil {
+		return repository.SearchOptions{}, err
+	}
+	for name, value := range req.Options {
+		args.flags[name] = value
+	}
+
+	opts := repository.SearchOptions{Keyword: strings.Join(args.positional, " ")}
+
+	if value, ok := args.flags[FlagSince]; ok {
+		if opts.Since, err = parseSince(value, now); err != nil {
+			return opts, err
+		}
+	}
+
+	if value, ok := args.flags[FlagSource]; ok {
+		opts.Source = strings.TrimSpace(value)
+		if length := utf8.RuneCountInString(opts.Source); length < 2 || length > 50 {
+			return opts, argErrorf("`--source` harus 2-50 karakter, misal: `--source wired`")
+		}
+	}
+
+	if value, ok := args.flags[FlagLang]; ok {
+		opts.Language = strings.ToLower(strings.TrimSpace(value))
+		if !languagePattern.MatchString(opts.Language) {
+			return opts, argErrorf("`--lang` harus kode bahasa 2 huruf (ISO 639-1), misal: `en` atau `id`")
+		}
+	}
+
+	if value, ok := args.flags[FlagLimit]; ok {
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || limit < 1 || limit > maxSearchLimit {
+			return opts, argErrorf("`--limit` harus angka 1-%d", maxSearchLimit)
+		}
+		opts.Limit = limit
+	}
+
+	if value, ok := args.flags[FlagSort]; ok {
+		opts.Sort = strings.ToLower(strings.TrimSpace(value))
+		switch opts.Sort {
+		case repository.SortRelevance, repository.SortPopularity, repository.SortNewest:
+		default:
+			return opts, argErrorf("`--sort` harus salah satu dari: %s, %s, %s",
+				repository.SortRelevance, repository.SortPopularity, repository.SortNewest)
+		}
+	}
+
+	return opts, nil
+}
+
+// parseSince menerima durasi relatif (30m, 12h, 3d, 2w) atau tanggal YYYY-MM-DD,
+// paling jauh maxSearchSince ke belakang
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	invalid := argErrorf("`--since` harus durasi seperti `12h`, `3d` atau `2w`, atau tanggal `YYYY-MM-DD`")
+
+	var since time.Time
+	if match := sinceDurationPattern.FindStringSubmatch(value); match != nil {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil || amount == 0 {
+			return time.Time{}, invalid
+		}
+
+		unit := map[string]time.Duration{
+			"m": time.Minute,
+			"h": time.Hour,
+			"d": 24 * time.Hour,
+			"w": 7 * 24 * time.Hour,
+		}[match[2]]
+		if time.Duration(amount) > maxSearchSince/unit {
+			return time.Time{}, argErrorf("`--since` paling jauh %d hari ke belakang", int(maxSearchSince.Hours()/24))
+		}
+		since = now.Add(-time.Duration(amount) * unit)
+	} else {
+		date, err := time.ParseInLocation("2006-01-02", value, now.Location())
+		if err != nil {
+			return time.Time{}, invalid
+		}
+		if date.After(now) {
+			return time.Time{}, argErrorf("Tanggal `--since` tidak boleh di masa depan")
+		}
+		since = date
+	}
+
+	if now.Sub(since) > maxSearchSince {
+		return time.Time{}, argErrorf("`--since` paling jauh %d hari ke belakang", int(maxSearchSince.Hours()/24))
+	}
+	return since, nil
+}
+
+func hasFlag(flags []CommandFlag, name string) bool {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func flagList(flags []CommandFlag) string {
+	names := make([]string, len(flags))
+	for i, flag := range flags {
+		names[i] = "`--" + flag.Name + "`"
+	}
+	return strings.Join(names, ", ")
+}
+
+func flagExample(name string) string {
+	switch name {
+	case FlagSince:
+		return "3d"
+	case FlagSource:
+		return "wired"
+	case FlagLang:
+		return "en"
+	case FlagLimit:
+		return "10"
+	case FlagSort:
+		return repository.SortNewest
+	}
+	return "nilai"
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/repository"
+)
+
+func TestParseSearchOptions(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	opts, err := parseSearchOptions(CommandRequest{
+		Args:    `"large language models" --since 3d --source wired --limit 20`,
+		Options: map[string]string{FlagLimit: "5", FlagSort: "Newest"},
+	}, now)
+	if err != nil {
+		t.Fatalf("parseSearchOptions: %v", err)
+	}
+	want := repository.SearchOptions{
+		Keyword: "large language models",
+		Since:   now.Add(-72 * time.Hour),
+		Source:  "wired",
+		Limit:   5,
+		Sort:    repository.SortNewest,
+	}
+	if opts != want {
+		t.Errorf("opts = %+v, want %+v", opts, want)
+	}
+
+	for _, args := range []string{"ai --limit 0", "ai --limit 51", "ai --lang english", "ai --unknown x", "ai --since"} {
+		var argErr *ArgError
+		if _, err := parseSearchOptions(CommandRequest{Args: args}, now); !errors.As(err, &argErr) {
+			t.Errorf("%q: err = %v, want ArgError", args, err)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"discord-ai-tech-news/internal/response"
+)
+
+// Kategori command, urutannya dipakai di pesan help
+const (
+	CategoryNews     = "Berita"
+	CategorySchedule = "Jadwal"
+	CategoryGeneral  = "Umum"
+	CategoryAdmin    = "Admin"
+)
+
+var commandCategories = []string{CategoryNews, CategorySchedule, CategoryGeneral, CategoryAdmin}
+
+// CommandRequest adalah konteks satu pemanggilan command, baik dari pesan teks
+// maupun slash command
+type CommandRequest struct {
+	Args string // teks setelah nama command, misal keyword untuk search
+	// Subcommand adalah nama subcommand slash command, misal "show" pada /config show
+	Subcommand string
+	// Options berisi nilai flag atau option subcommand dari slash command, dengan key
+	// nama option; option channel berisi channel ID
+	Options     map[string]string
+	UserID      string
+	Username    string
+	GuildID     string
+	ChannelID   string
+	ChannelName string
+}
+
+type CommandHandler func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error)
+
+// CommandArg adalah argumen teks tunggal sebuah command. Di slash command
+// argumen ini didaftarkan sebagai option string.
+type CommandArg struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// CommandFlag adalah flag opsional `--nama nilai`. Di slash command flag
+// didaftarkan sebagai option string, atau option integer dengan batas
+// MinValue-MaxValue jika Type bernilai OptionInteger; Choices membatasi nilainya.
+type CommandFlag struct {
+	Name        string
+	Description string
+	Type        CommandOptionType
+	Choices     []string
+	MinValue    int
+	MaxValue    int
+}
+
+// Tipe option subcommand dan flag
+const (
+	OptionString CommandOptionType = iota
+	OptionChannel
+	OptionInteger
+)
+
+type CommandOptionType int
+
+// CommandChoice adalah pilihan nilai option; Name ditampilkan di Discord
+type CommandChoice struct {
+	Name  string
+	Value string
+}
+
+// CommandOption adalah option di dalam subcommand slash command
+type CommandOption struct {
+	Name        string
+	Description string
+	Type        CommandOptionType
+	Required    bool
+	Choices     []CommandChoice
+}
+
+// Subcommand adalah subcommand slash command, misal /config show
+type Subcommand struct {
+	Name        string
+	Description string
+	Options     []CommandOption
+}
+
+// Command adalah definisi satu command bot. Dispatch command teks, pesan help dan
+// registrasi slash command semuanya dibuat dari definisi ini.
+type Command struct {
+	Name        string
+	Aliases     []string // hanya untuk command teks
+	Description string
+	Usage       string
+	Examples    []string
+	Category    string
+	Arg         *CommandArg
+	Flags       []CommandFlag
+	// Ephemeral: balasan slash command hanya terlihat oleh pemanggil
+	Ephemeral bool
+	// SlashOnly: command dengan Subcommands (misal /config) yang hanya tersedia
+	// sebagai slash command
+	SlashOnly   bool
+	Subcommands []Subcommand
+	// AdminOnly: hanya untuk member dengan izin Manage Server dan tidak tersedia di DM
+	AdminOnly bool
+	// Upstream: command memanggil sumber berita, jadi ikut memakai rate limit guild
+	// yang menjaga kuota harian NewsAPI
+	Upstream bool
+	Handler  CommandHandler
+}
+
+// CommandRegistry menyimpan command terdaftar sesuai urutan registrasi
+type CommandRegistry struct {
+	commands []*Command
+	text     map[string]*Command // nama dan alias command teks
+	slash    map[string]*Command
+}
+
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{
+		text:  make(map[string]*Command),
+		slash: make(map[string]*Command),
+	}
+}
+
+// Register menambah command; nama atau alias ganda adalah kesalahan program
+func (r *CommandRegistry) Register(command Command) {
+	if _, exists := r.slash[command.Name]; exists {
+		panic(fmt.Sprintf("command %q registered twice", command.Name))
+	}
+
+	cmd := &command
+	r.slash[cmd.Name] = cmd
+	r.commands = append(r.commands, cmd)
+
+	if cmd.SlashOnly {
+		return
+	}
+	for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+		if _, exists := r.text[name]; exists {
+			panic(fmt.Sprintf("command name or alias %q registered twice", name))
+		}
+		r.text[name] = cmd
+	}
+}
+
+// Commands mengembalikan semua command sesuai urutan registrasi
+func (r *CommandRegistry) Commands() []*Command {
+	return r.commands
+}
+
+// LookupText mencari command teks berdasarkan nama atau alias
+func (r *CommandRegistry) LookupText(name string) (*Command, bool) {
+	cmd, ok := r.text[strings.ToLower(name)]
+	return cmd, ok
+}
+
+// LookupSlash mencari command berdasarkan nama slash command
+func (r *CommandRegistry) LookupSlash(name string) (*Command, bool) {
+	cmd, ok := r.slash[name]
+	return cmd, ok
+}
+
+// Help membuat HelpResponse dari semua command, dikelompokkan per kategori
+func (r *CommandRegistry) Help() *response.HelpResponse {
+	var commands []response.CommandInfo
+	var examples []string
+
+	for _, category := range commandCategories {
+		for _, cmd := range r.commands {
+			if cmd.Category != category {
+				continue
+			}
+			commands = append(commands, response.CommandInfo{
+				Name:        cmd.Name,
+				Aliases:     cmd.Aliases,
+				Description: cmd.Description,
+				Usage:       cmd.Usage,
+				Examples:    cmd.Examples,
+				Category:    cmd.Category,
+			})
+			examples = append(examples, cmd.Examples...)
+		}
+	}
+
+	return response.NewHelpResponse().
+		WithCommands(commands, examples).
+		Build().(*response.HelpResponse)
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+)
+
+// registerCommands mendaftarkan semua command bot. Menambah command cukup di sini:
+// dispatch teks, pesan help dan slash command ikut terbentuk dari definisinya.
+func (u *MessageUsecase) registerCommands() {
+	u.commands.Register(Command{
+		Name:        "news",
+		Aliases:     []string{"berita", "tech", "teknologi"},
+		Description: "Dapatkan berita teknologi terbaru",
+		Usage:       "/news [--since 12h] [--source hackernews] [--lang en] [--limit 5] [--sort newest]",
+		Examples:    []string{"/news --source hackernews --sort popularity"},
+		Category:    CategoryNews,
+		Flags:       newsFlags,
+		Upstream:    true,
+		Handler:     u.handleNewsRequest,
+	})
+	u.commands.Register(Command{
+		Name:        "search",
+		Aliases:     []string{"cari"},
+		Description: "Cari berita berdasarkan kata kunci",
+		Usage:       "/search <keyword> [--since 3d] [--source wired] [--lang en] [--limit 10] [--sort relevance|popularity|newest]",
+		Examples:    []string{"/search AI", "!cari blockchain --sort newest", `/search "large language models" --since 3d --source wired`},
+		Category:    CategoryNews,
+		Arg:         &CommandArg{Name: "keyword", Description: "Kata kunci pencarian, misal: AI, \"large language models\"", Required: true},
+		Flags:       newsFlags,
+		Upstream:    true,
+		Handler:     u.handleSearchRequest,
+	})
+	u.commands.Register(Command{
+		Name:        "watch",
+		Description: "Dapatkan DM saat ada artikel baru dengan kata kunci ini",
+		Usage:       "/watch <keyword>",
+		Examples:    []string{"/watch kubernetes"},
+		Category:    CategoryNews,
+		Arg:         &CommandArg{Name: "term", Description: "Kata kunci, misal: kubernetes", Required: true},
+		Ephemeral:   true,
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			return u.Watch(req.UserID, req.Args)
+		},
+	})
+	u.commands.Register(Command{
+		Name:        "unwatch",
+		Description: "Hapus kata kunci dari watchlist",
+		Usage:       "/unwatch <keyword>",
+		Category:    CategoryNews,
+		Arg:         &CommandArg{Name: "term", Description: "Kata kunci dari /watchlist", Required: true},
+		Ephemeral:   true,
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			return u.Unwatch(req.UserID, req.Args)
+		},
+	})
+	u.commands.Register(Command{
+		Name:        "watchlist",
+		Description: "Lihat kata kunci yang kamu pantau",
+		Usage:       "/watchlist",
+		Category:    CategoryNews,
+		Ephemeral:   true,
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			return u.Watchlist(req.UserID)
+		},
+	})
+	u.commands.Register(Command{
+		Name:        "cron",
+		Aliases:     []string{"jadwal"}, // "schedule" adalah nama slash command admin
+		Description: "Lihat status jadwal berita otomatis",
+		Usage:       "/cron",
+		Category:    CategorySchedule,
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			return u.handleCronStatusRequest(ctx)
+		},
+	})
+	u.commands.Register(Command{
+		Name:        "hello",
+		Aliases:     []string{"hi", "halo", "hallo"},
+		Description: "Sapa bot",
+		Usage:       "/hello",
+		Category:    CategoryGeneral,
+		Handler:     u.handleHello,
+	})
+	u.commands.Register(Command{
+		Name:        "help",
+		Aliases:     []string{"bantuan"},
+		Description: "Tampilkan daftar command",
+		Usage:       "/help",
+		Category:    CategoryGeneral,
+		Ephemeral:   true,
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			return response.TextMessage(u.formatter.FormatHelpResponse(u.commands.Help())), nil
+		},
+	})
+	u.commands.Register(Command{
+		Name:        "ping",
+		Description: "Cek status koneksi bot",
+		Usage:       "/ping",
+		Category:    CategoryGeneral,
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			resp := response.NewBotResponse("ping").
+				WithDisplayText("🏓 Pong! Bot sedang online dan siap melayani!").
+				Build().(*response.BotResponse)
+			return response.TextMessage(u.formatter.FormatBotResponse(resp)), nil
+		},
+	})
+	u.commands.Register(Command{
+		Name:        "status",
+		Description: "Lihat status bot",
+		Usage:       "/status",
+		Category:    CategoryGeneral,
+		Handler:     u.handleStatus,
+	})
+	u.commands.Register(Command{
+		Name:        "config",
+		Description: "Atur channel, jadwal dan zona waktu berita untuk server ini",
+		Usage:       "/config <show|add-news-channel|...>",
+		Category:    CategoryAdmin,
+		SlashOnly:   true,
+		AdminOnly:   true,
+		Ephemeral:   true,
+		Subcommands: []Subcommand{
+			{Name: ConfigActionShow, Description: "Lihat konfigurasi server"},
+			{Name: ConfigActionAddNewsChannel, Description: "Tambah channel tujuan berita otomatis", Options: []CommandOption{channelOption}},
+			{Name: ConfigActionRemoveNewsChannel, Description: "Hapus channel tujuan berita otomatis", Options: []CommandOption{channelOption}},
+			{Name: ConfigActionAddCommandChannel, Description: "Izinkan command teks di channel ini", Options: []CommandOption{channelOption}},
+			{Name: ConfigActionRemoveCommandChannel, Description: "Cabut izin command teks di channel ini", Options: []CommandOption{channelOption}},
+			{Name: ConfigActionSchedules, Description: "Atur jadwal yang aktif", Options: []CommandOption{
+				{Name: "names", Description: "Nama jadwal dipisah koma (misal: morning,evening) atau 'all'", Required: true},
+			}},
+			{Name: ConfigActionTimezone, Description: "Atur zona waktu jadwal server ini", Options: []CommandOption{
+				{Name: "name", Description: "Zona waktu IANA (misal: Asia/Makassar) atau 'default'", Required: true},
+			}},
+		},
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			value := req.Options["names"]
+			if req.Subcommand == ConfigActionTimezone {
+				value = req.Options["name"]
+			}
+			return u.ConfigureGuild(req.GuildID, req.Subcommand, req.Options["channel"], value)
+		},
+	})
+	u.commands.Register(Command{
+		Name:        "schedule",
+		Description: "Kelola jadwal berita otomatis server ini",
+		Usage:       "/schedule <add|list|pause|resume|remove>",
+		Category:    CategoryAdmin,
+		SlashOnly:   true,
+		AdminOnly:   true,
+		Ephemeral:   true,
+		Subcommands: []Subcommand{
+			{Name: ScheduleActionAdd, Description: "Tambah jadwal baru", Options: []CommandOption{
+				{Name: "name", Description: "Nama jadwal, misal: ai-pagi", Required: true},
+				{Name: "cron", Description: "Cron expression (menit jam tanggal bulan hari), misal: 0 8 * * 1-5", Required: true},
+				channelOption,
+				{Name: "timezone", Description: "Zona waktu IANA untuk cron, default mengikuti server"},
+				{Name: "header", Description: "Judul pesan berita"},
+				{Name: "query", Description: "Kirim hasil pencarian kata kunci ini alih-alih berita terbaru"},
+				{Name: "kind", Description: "Jenis jadwal, default berita terbaru", Choices: []CommandChoice{
+					{Name: "Berita terbaru", Value: scheduleKindNewsChoice},
+					{Name: "Digest mingguan (top story 7 hari)", Value: repository.ScheduleKindWeeklyDigest},
+					{Name: "Digest bulanan (top story sebulan)", Value: repository.ScheduleKindMonthlyDigest},
+				}},
+			}},
+			{Name: ScheduleActionRemove, Description: "Hapus jadwal", Options: []CommandOption{scheduleIDOption}},
+			{Name: ScheduleActionList, Description: "Lihat semua jadwal"},
+			{Name: ScheduleActionPause, Description: "Hentikan sementara jadwal", Options: []CommandOption{scheduleIDOption}},
+			{Name: ScheduleActionResume, Description: "Lanjutkan jadwal yang di-pause", Options: []CommandOption{scheduleIDOption}},
+		},
+		Handler: func(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+			cmd := ScheduleCommand{
+				Action:    req.Subcommand,
+				ID:        req.Options["id"],
+				Name:      req.Options["name"],
+				CronExpr:  req.Options["cron"],
+				Timezone:  req.Options["timezone"],
+				ChannelID: req.Options["channel"],
+				Header:    req.Options["header"],
+				Query:     req.Options["query"],
+				Kind:      req.Options["kind"],
+			}
+			if cmd.Kind == scheduleKindNewsChoice {
+				cmd.Kind = repository.ScheduleKindNews
+			}
+			return u.ManageSchedule(req.GuildID, cmd)
+		},
+	})
+}
+
+var (
+	channelOption = CommandOption{
+		Name:        "channel",
+		Description: "Text channel",
+		Type:        OptionChannel,
+		Required:    true,
+	}
+	scheduleIDOption = CommandOption{Name: "id", Description: "ID jadwal dari /schedule list", Required: true}
+)
+
+// scheduleKindNewsChoice mewakili ScheduleKindNews (string kosong) di pilihan option
+// kind, karena nilai choice Discord tidak boleh kosong
+const scheduleKindNewsChoice = "news"
+
+// handleStatus melaporkan status komponen bot dari HealthService, sama dengan /readyz
+func (u *MessageUsecase) handleStatus(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+	builder := response.NewStatusResponse()
+	if u.health == nil {
+		return response.TextMessage(u.formatter.FormatStatusResponse(builder.WithStatus("Online").Build().(*response.StatusResponse))), nil
+	}
+
+	checks, ready := u.health.Readiness()
+	services := make(map[string]string, len(checks))
+	for _, check := range checks {
+		name := statusServiceNames[check.Name]
+		if name == "" {
+			name = check.Name
+		}
+		services[name] = check.Detail
+	}
+
+	status := "Online dan berjalan normal"
+	if !ready {
+		status = "Online, tetapi ada komponen yang bermasalah"
+	}
+	resp := builder.
+		WithStatus(status).
+		WithServices(services).
+		Build().(*response.StatusResponse)
+	return response.TextMessage(u.formatter.FormatStatusResponse(resp)), nil
+}
+
+// statusServiceNames adalah label komponen HealthService di balasan /status
+var statusServiceNames = map[string]string{
+	"discord_gateway": "Discord Gateway",
+	"news_source":     "Sumber Berita",
+	"scheduler":       "Scheduler",
+	"storage":         "Database",
+}
+
+func (u *MessageUsecase) handleHello(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+	builder := response.NewBotResponse("hello").
+		WithDisplayText("Hello! 👋 Saya adalah **AI Tech News Bot**\n\n🤖 Saya bisa membantu Anda mendapatkan berita teknologi terbaru!\n\n💡 Ketik `help` untuk melihat command yang tersedia.")
+	if req.UserID != "" {
+		builder = builder.WithUserInfo(req.UserID, req.Username, false)
+	}
+	if req.ChannelID != "" {
+		builder = builder.WithChannelInfo(req.ChannelID, req.ChannelName, "text")
+	}
+
+	resp := builder.Build().(*response.BotResponse)
+	return response.TextMessage(u.formatter.FormatBotResponse(resp)), nil
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/service"
+)
+
+func TestTextAliasesDoNotShadowSlashCommands(t *testing.T) {
+	u := NewMessageUsecase(nil, nil, nil, nil, nil, nil, nil)
+
+	for _, cmd := range u.Commands() {
+		if !cmd.SlashOnly {
+			continue
+		}
+		if owner, ok := u.commands.LookupText(cmd.Name); ok {
+			t.Errorf("text command %q uses the name of slash-only command /%s", owner.Name, cmd.Name)
+		}
+	}
+}
+
+func TestAdminCommandsDeclareSubcommands(t *testing.T) {
+	u := NewMessageUsecase(nil, nil, nil, nil, nil, nil, nil)
+
+	for _, name := range []string{"config", "schedule"} {
+		cmd, ok := u.LookupCommand(name)
+		if !ok {
+			t.Fatalf("/%s is not registered", name)
+		}
+		if !cmd.AdminOnly || !cmd.SlashOnly || len(cmd.Subcommands) == 0 || cmd.Handler == nil {
+			t.Errorf("/%s = %+v, want an admin slash command with subcommands and a handler", name, cmd)
+		}
+	}
+
+	reply, err := u.ProcessMessage(context.Background(), "!config show", CommandRequest{})
+	if err != nil || reply == nil || !strings.Contains(reply.Content, "slash command `/config`") {
+		t.Errorf("text !config = %+v, %v; want a slash-only hint", reply, err)
+	}
+}
+
+type fakeGateway struct{ ready bool }
+
+func (g fakeGateway) GatewayReady() bool { return g.ready }
+func (g fakeGateway) Heartbeat() (time.Duration, time.Time) {
+	return 50 * time.Millisecond, time.Now()
+}
+
+type fakeScheduler struct{}
+
+func (fakeScheduler) Running() bool { return true }
+
+type fakeFetches struct{ status service.FetchStatus }
+
+func (f fakeFetches) LastFetch() service.FetchStatus { return f.status }
+
+type fakeStorage struct{ err error }
+
+func (s fakeStorage) Ping() error { return s.err }
+
+func TestStatusReportsHealthChecks(t *testing.T) {
+	u := NewMessageUsecase(nil, nil, nil, nil, nil, nil, nil)
+
+	u.SetHealth(service.NewHealthService(fakeGateway{ready: true}, fakeScheduler{}, fakeFetches{}, fakeStorage{}))
+	reply, err := u.RunCommand(context.Background(), "status", CommandRequest{})
+	if err != nil {
+		t.Fatalf("RunCommand: %v", err)
+	}
+	for _, want := range []string{"berjalan normal", "✅ Discord Gateway: healthy", "✅ Database: healthy"} {
+		if !strings.Contains(reply.Content, want) {
+			t.Errorf("healthy /status missing %q:\n%s", want, reply.Content)
+		}
+	}
+
+	u.SetHealth(service.NewHealthService(fakeGateway{ready: false}, fakeScheduler{}, fakeFetches{}, fakeStorage{err: errors.New("database closed")}))
+	reply, err = u.RunCommand(context.Background(), "status", CommandRequest{})
+	if err != nil {
+		t.Fatalf("RunCommand: %v", err)
+	}
+	for _, want := range []string{"bermasalah", "❌ Discord Gateway: gateway disconnected", "❌ Database: database closed"} {
+		if !strings.Contains(reply.Content, want) {
+			t.Errorf("degraded /status missing %q:\n%s", want, reply.Content)
+		}
+	}
+}
+
+func TestGuildLimitOnlyChargesUpstreamCommands(t *testing.T) {
+	limiter := service.NewRateLimiter(map[string]service.RateLimit{
+		service.RateScopeUser:    {Limit: 3, Period: time.Minute},
+		service.RateScopeChannel: {Limit: 100, Period: time.Minute},
+		service.RateScopeGuild:   {Limit: 1, Period: 24 * time.Hour},
+	})
+	u := NewMessageUsecase(nil, nil, nil, nil, nil, nil, limiter)
+	news, _ := u.LookupCommand("news")
+	search, _ := u.LookupCommand("search")
+	help, _ := u.LookupCommand("help")
+	if !news.Upstream || !search.Upstream || help.Upstream {
+		t.Fatal("only /news and /search should be marked as upstream commands")
+	}
+
+	request := func(userID string) CommandRequest {
+		return CommandRequest{UserID: userID, ChannelID: "channel-1", GuildID: "guild-1"}
+	}
+
+	// Command tanpa request ke sumber berita tidak memakai jatah guild
+	for _, userID := range []string{"user-1", "user-2", "user-3"} {
+		if reply, _ := u.Throttle(help, request(userID)); reply != nil {
+			t.Fatalf("/help by %s was throttled: %s", userID, reply.Content)
+		}
+	}
+	if reply, _ := u.Throttle(news, request("user-1")); reply != nil {
+		t.Fatalf("first /news was throttled: %s", reply.Content)
+	}
+	reply, _ := u.Throttle(search, request("user-2"))
+	if reply == nil || !strings.Contains(reply.Content, "batas pengambilan berita") {
+		t.Fatalf("/search over the guild limit = %v, want the guild cooldown", reply)
+	}
+	if reply, _ := u.Throttle(nil, request("user-3")); reply != nil {
+		t.Errorf("unknown command was charged to the guild: %s", reply.Content)
+	}
+
+	// Limit user tetap berlaku untuk semua command
+	if reply, _ := u.Throttle(help, request("user-1")); reply != nil {
+		t.Fatalf("user-1's third command was throttled: %s", reply.Content)
+	}
+	if reply, _ := u.Throttle(help, request("user-1")); reply == nil {
+		t.Error("user limit should still apply to /help")
+	}
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/service"
+)
+
+// fakeScheduleManager menyajikan status scheduler tetap; method ScheduleManager lain
+// tidak dipakai /cron
+type fakeScheduleManager struct {
+	service.ScheduleManager
+	state    string
+	statuses []service.JobStatus
+	runs     []service.RunRecord
+}
+
+func (f fakeScheduleManager) State() string                              { return f.state }
+func (f fakeScheduleManager) JobStatuses() []service.JobStatus           { return f.statuses }
+func (f fakeScheduleManager) DefaultLocation() *time.Location            { return time.UTC }
+func (f fakeScheduleManager) RunHistory(string, int) []service.RunRecord { return f.runs }
+
+func TestCronStatusReflectsScheduler(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	scheduler := fakeScheduleManager{
+		statuses: []service.JobStatus{{
+			ScheduleID: "morning",
+			Name:       "morning",
+			CronExpr:   "0 8 * * *",
+			Location:   jakarta,
+			NextRun:    time.Date(2025, 3, 11, 1, 0, 0, 0, time.UTC),
+			LastResult: &service.RunRecord{Success: true, ArticlesPosted: 5, Channels: 2, Duration: 1200 * time.Millisecond},
+		}},
+		runs: []service.RunRecord{{Name: "morning", StartedAt: time.Date(2025, 3, 10, 1, 0, 0, 0, time.UTC), Success: false, Error: "no news"}},
+	}
+
+	tests := []struct {
+		state string
+		want  string
+	}{
+		{service.SchedulerRunning, "🔥 **Status**: running • 1 job aktif"},
+		{service.SchedulerNotLeader, "💤 **Status**: not leader • 1 job aktif"},
+		{service.SchedulerStopped, "🛑 **Status**: stopped • 1 job aktif"},
+	}
+	for _, tt := range tests {
+		scheduler.state = tt.state
+		u := NewMessageUsecase(nil, nil, scheduler, nil, nil, nil, nil)
+
+		reply, err := u.RunCommand(context.Background(), "cron", CommandRequest{})
+		if err != nil {
+			t.Fatalf("RunCommand: %v", err)
+		}
+		for _, want := range []string{
+			tt.want,
+			"• **morning** `0 8 * * *` (Asia/Jakarta): berikutnya 11 Mar 08:00 WIB • terakhir ✅ 5 artikel ke 2 channel (1.2s)",
+			"• **morning** 10 Mar 01:00 UTC: ❌ no news",
+		} {
+			if !strings.Contains(reply.Content, want) {
+				t.Errorf("%s: /cron missing %q:\n%s", tt.state, want, reply.Content)
+			}
+		}
+	}
+}
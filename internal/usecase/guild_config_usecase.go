@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+	"discord-ai-tech-news/internal/service"
+)
+
+// Aksi untuk command /config
+const (
+	ConfigActionShow                 = "show"
+	ConfigActionAddNewsChannel       = "add-news-channel"
+	ConfigActionRemoveNewsChannel    = "remove-news-channel"
+	ConfigActionAddCommandChannel    = "add-command-channel"
+	ConfigActionRemoveCommandChannel = "remove-command-channel"
+	ConfigActionSchedules            = "schedules"
+	ConfigActionTimezone             = "timezone"
+)
+
+// ConfigureGuild menjalankan aksi /config untuk sebuah guild. channelID dipakai
+// oleh aksi channel, value dipakai oleh aksi schedules ("all" atau daftar dipisah koma)
+// dan timezone (nama zona IANA atau "default").
+func (u *MessageUsecase) ConfigureGuild(guildID, action, channelID, value string) (*response.DiscordMessage, error) {
+	if guildID == "" {
+		return response.TextMessage("❌ Command `/config` hanya bisa dipakai di dalam server."), nil
+	}
+
+	// Konfigurasi dipakai job terjadwal, yang hanya berjalan di leader
+	if action != ConfigActionShow {
+		if err := u.cron.RequireLeader(); err != nil {
+			log.Printf("⚠️ Rejected /config %s for guild %s: %v", action, guildID, err)
+			return notLeaderMessage(), nil
+		}
+	}
+
+	var (
+		config *repository.GuildConfig
+		err    error
+	)
+
+	switch action {
+	case ConfigActionShow:
+		config, err = u.guildConfig.Get(guildID)
+	case ConfigActionAddNewsChannel:
+		config, err = u.guildConfig.AddNewsChannel(guildID, channelID)
+	case ConfigActionRemoveNewsChannel:
+		config, err = u.guildConfig.RemoveNewsChannel(guildID, channelID)
+	case ConfigActionAddCommandChannel:
+		config, err = u.guildConfig.AddCommandChannel(guildID, channelID)
+	case ConfigActionRemoveCommandChannel:
+		config, err = u.guildConfig.RemoveCommandChannel(guildID, channelID)
+	case ConfigActionSchedules:
+		config, err = u.guildConfig.SetSchedules(guildID, parseScheduleList(value))
+		var unknownErr *service.UnknownScheduleError
+		if errors.As(err, &unknownErr) {
+			return u.unknownScheduleMessage(guildID, unknownErr.Names), nil
+		}
+	case ConfigActionTimezone:
+		config, err = u.guildConfig.SetTimezone(guildID, value)
+		// Jadwal didaftarkan ulang di zona waktu baru oleh CronService (lihat GuildConfigService.OnChange)
+		if errors.Is(err, service.ErrInvalidTimezone) {
+			return response.TextMessage(fmt.Sprintf("❌ Zona waktu `%s` tidak dikenal. Gunakan nama IANA, misal `Asia/Jakarta` atau `Asia/Makassar`.", value)), nil
+		}
+	default:
+		return response.TextMessage(fmt.Sprintf("❓ Aksi config `%s` tidak dikenal.", action)), nil
+	}
+
+	if err != nil {
+		log.Printf("❌ ERROR: Failed to %s for guild %s: %v", action, guildID, err)
+		return response.TextMessage("❌ **Error**: Gagal menyimpan konfigurasi server."), err
+	}
+
+	return response.TextMessage(u.formatGuildConfig(config)), nil
+}
+
+// parseScheduleList mengubah "morning, evening" menjadi daftar jadwal; "all" atau kosong berarti semua
+func parseScheduleList(value string) []string {
+	if strings.EqualFold(strings.TrimSpace(value), "all") {
+		return nil
+	}
+
+	var schedules []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			schedules = append(schedules, name)
+		}
+	}
+	return schedules
+}
+
+// unknownScheduleMessage menjelaskan nama jadwal yang ditolak SetSchedules beserta
+// jadwal yang tersedia untuk guild
+func (u *MessageUsecase) unknownScheduleMessage(guildID string, unknown []string) *response.DiscordMessage {
+	message := fmt.Sprintf("❌ Jadwal tidak dikenal: `%s`.", strings.Join(unknown, "`, `"))
+
+	names, listErr := u.guildConfig.ScheduleNames(guildID)
+	if listErr != nil {
+		log.Printf("⚠️ Failed to list schedules for guild %s: %v", guildID, listErr)
+	} else if len(names) > 0 {
+		message += fmt.Sprintf("\n📅 Jadwal yang tersedia: `%s` (atau `all`)", strings.Join(names, "`, `"))
+	}
+	return response.TextMessage(message)
+}
+
+func (u *MessageUsecase) formatGuildConfig(config *repository.GuildConfig) string {
+	var message strings.Builder
+	message.WriteString("⚙️ **Konfigurasi Server**\n\n")
+
+	message.WriteString("📰 **News Channel**: ")
+	if len(config.NewsChannelIDs) == 0 {
+		message.WriteString("default (#🔥┃ai-tech-news atau fallback)\n")
+	} else {
+		message.WriteString(channelMentions(config.NewsChannelIDs) + "\n")
+	}
+
+	message.WriteString("💬 **Command Channel**: ")
+	if len(config.CommandChannelIDs) == 0 {
+		message.WriteString("default (#🔥┃ai-tech-news, #🕹️┃dev-talk)\n")
+	} else {
+		message.WriteString(channelMentions(config.CommandChannelIDs) + "\n")
+	}
+
+	message.WriteString("📅 **Jadwal Aktif**: ")
+	if len(config.EnabledSchedules) == 0 {
+		message.WriteString("semua jadwal\n")
+	} else {
+		message.WriteString(strings.Join(config.EnabledSchedules, ", ") + "\n")
+	}
+
+	message.WriteString("🌍 **Zona Waktu**: ")
+	if config.Timezone == "" {
+		message.WriteString(fmt.Sprintf("default (%s)\n", u.cron.DefaultLocation()))
+	} else {
+		message.WriteString(config.Timezone + "\n")
+	}
+
+	return message.String()
+}
+
+func channelMentions(channelIDs []string) string {
+	mentions := make([]string, len(channelIDs))
+	for i, channelID := range channelIDs {
+		mentions[i] = "<#" + channelID + ">"
+	}
+	return strings.Join(mentions, ", ")
+}
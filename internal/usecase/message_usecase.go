@@ -2,12 +2,9 @@ package usecase
 
 import (
 	"context"
- 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -31,96 +28,265 @@ func HandleDiscordMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
 
 type MessageUsecase struct {
 	newsService service.NewsService
+	guildConfig *service.GuildConfigService
+	cron        service.ScheduleManager
+	digest      *service.DigestService
+	watch       *service.WatchService
 	formatter   *response.DiscordFormatter
+	renderer    *response.EmbedRenderer
+	pages       *PaginationStore
+	limiter     *service.RateLimiter
+	commands    *CommandRegistry
+	health      *service.HealthService
 }
 
-func NewMessageUsecase(newsService service.NewsService) *MessageUsecase {
-	return &MessageUsecase{
+func NewMessageUsecase(newsService service.NewsService, guildConfig *service.GuildConfigService, cron service.ScheduleManager, digest *service.DigestService, watch *service.WatchService, pages *PaginationStore, limiter *service.RateLimiter) *MessageUsecase {
+	u := &MessageUsecase{
 		newsService: newsService,
+		guildConfig: guildConfig,
+		cron:        cron,
+		digest:      digest,
+		watch:       watch,
 		formatter:   response.NewDiscordFormatter(),
+		renderer:    response.NewEmbedRenderer(),
+		pages:       pages,
+		limiter:     limiter,
+		commands:    NewCommandRegistry(),
 	}
+	u.registerCommands()
+	return u
 }
 
-func (u *MessageUsecase) ProcessMessage(ctx context.Context, content string) (string, error) {
+// SetHealth memasang HealthService untuk command /status. Sama seperti bot, health
+// check baru bisa dibuat setelah usecase, karena ikut memeriksa koneksi gateway bot.
+func (u *MessageUsecase) SetHealth(health *service.HealthService) {
+	u.health = health
+}
+
+// CommandChannelAllowed menentukan apakah command boleh diproses di channel ini
+func (u *MessageUsecase) CommandChannelAllowed(guildID, channelID, channelName string) bool {
+	return u.guildConfig.CommandChannelAllowed(guildID, channelID, channelName)
+}
+
+// TrackPagination menyimpan state halaman setelah pesan terkirim dan ID-nya diketahui
+func (u *MessageUsecase) TrackPagination(messageID string, reply *response.DiscordMessage) {
+	if reply == nil || reply.Pages == nil {
+		return
+	}
+	u.pages.Save(messageID, reply.Pages)
+}
+
+// GuildJoined dipanggil saat bot bergabung ke guild, agar jadwal langsung
+// didaftarkan di zona waktu guild tersebut
+func (u *MessageUsecase) GuildJoined(guildID string) {
+	u.cron.GuildJoined(guildID)
+}
+
+// RecordReaction menghitung reaksi user pada pesan berita bot untuk ranking digest.
+// delta bernilai 1 saat reaksi ditambahkan dan -1 saat dihapus; emoji menentukan
+// artikel yang direaksi (lihat DigestService.RecordReaction).
+func (u *MessageUsecase) RecordReaction(messageID, emoji string, delta int) {
+	if err := u.digest.RecordReaction(messageID, emoji, delta); err != nil {
+		log.Printf("⚠️ Failed to record reaction on message %s: %v", messageID, err)
+	}
+}
+
+// TurnPage merender halaman sebelum/sesudahnya untuk tombol Previous/Next.
+// Mengembalikan nil jika sesi halaman sudah kedaluwarsa.
+func (u *MessageUsecase) TurnPage(messageID string, delta int) *response.DiscordMessage {
+	pages, ok := u.pages.Turn(messageID, delta)
+	if !ok {
+		return nil
+	}
+	return u.renderer.RenderPage(pages)
+}
+
+// ProcessMessage menjalankan command teks yang diawali prefix "/" atau "!".
+// Pesan tanpa prefix diabaikan (reply nil). req berisi konteks pengirim; Args diisi di sini.
+func (u *MessageUsecase) ProcessMessage(ctx context.Context, content string, req CommandRequest) (*response.DiscordMessage, error) {
 	content = strings.TrimSpace(content)
-	originalCommand := strings.ToLower(content)
 
 	botPrefixes := []string{"/", "!"}
 	hasPrefix := false
-	command := originalCommand
 
 	for _, prefix := range botPrefixes {
-		if strings.HasPrefix(originalCommand, prefix) {
-			command = strings.TrimPrefix(originalCommand, prefix)
+		if strings.HasPrefix(content, prefix) {
+			content = strings.TrimPrefix(content, prefix)
 			hasPrefix = true
 			break
 		}
 	}
 
 	if !hasPrefix {
-		return "", nil
+		return nil, nil
 	}
 
-	switch command {
-	case "news", "berita", "tech", "teknologi":
-		return u.handleNewsRequest(ctx)
-	case "hello", "hi", "halo", "hallo":
-		resp := response.NewBotResponse("hello").
-			WithDisplayText("Hello! 👋 Saya adalah **AI Tech News Bot Dev**\n\n🤖 Saya bisa membantu Anda mendapatkan berita teknologi terbaru!\n\n💡 Ketik `help` untuk melihat command yang tersedia.").
-			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
-	case "help", "bantuan":
-		resp := response.NewBotResponse("help").
-			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
-	case "ping":
-		resp := response.NewBotResponse("ping").
-			WithDisplayText("🏓 Pong! Bot sedang online dan siap melayani!").
-			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
-	case "status":
-		services := map[string]string{
-			"News API": "Ready",
-			"Discord":  "Connected",
+	name, args, _ := strings.Cut(content, " ")
+	req.Args = strings.TrimSpace(args)
+
+	cmd, ok := u.commands.LookupText(name)
+	if !ok {
+		// Command admin seperti "!config" diarahkan ke slash command-nya
+		if cmd, ok = u.commands.LookupSlash(strings.ToLower(name)); !ok {
+			return u.unknownCommand(name, req.Args)
 		}
-		resp := response.NewStatusResponse().
-			WithStatus("Online dan berjalan normal").
-			WithServices(services).
-			Build().(*response.StatusResponse)
-		return u.formatter.FormatStatusResponse(resp), nil
-	case "cron", "schedule", "jadwal":
-		return u.handleCronStatusRequest(ctx)
-	default:
-		// Check if it's a search command
-		if strings.HasPrefix(command, "search ") || strings.HasPrefix(command, "cari ") {
-			keyword := strings.TrimPrefix(command, "search ")
-			keyword = strings.TrimPrefix(keyword, "cari ")
-			keyword = strings.TrimSpace(keyword)
-			if keyword != "" {
-				return u.handleSearchRequest(ctx, keyword)
-			}
+	}
+
+	// Balasan cooldown hanya dikirim sekali agar bot tidak ikut membanjiri channel
+	if reply, firstDenial := u.Throttle(cmd, req); reply != nil {
+		if !firstDenial {
+			return nil, nil
 		}
+		return reply, nil
+	}
+	if cmd.SlashOnly {
+		return response.TextMessage(fmt.Sprintf("ℹ️ Command ini hanya tersedia sebagai slash command `/%s`.", cmd.Name)), nil
+	}
+	return u.runCommand(ctx, cmd, req)
+}
+
+// Throttle mengambil token rate limit user dan channel untuk satu command, ditambah
+// guild jika command memanggil sumber berita (Command.Upstream); cmd nil untuk command
+// yang tidak dikenal. Mengembalikan balasan cooldown jika salah satu limit habis;
+// firstDenial false berarti pemanggil sudah pernah diberi tahu untuk cooldown yang sama.
+// Slash command di-throttle oleh interaction handler sebelum RunCommand.
+func (u *MessageUsecase) Throttle(cmd *Command, req CommandRequest) (reply *response.DiscordMessage, firstDenial bool) {
+	keys := []service.RateKey{
+		{Scope: service.RateScopeUser, ID: req.UserID},
+		{Scope: service.RateScopeChannel, ID: req.ChannelID},
+	}
+	if cmd != nil && cmd.Upstream {
+		keys = append(keys, service.RateKey{Scope: service.RateScopeGuild, ID: req.GuildID})
+	}
+
+	decision := u.limiter.Allow(keys...)
+	if decision.Allowed {
+		return nil, false
+	}
+
+	log.Printf("⏳ [RATE LIMIT] %s limit reached for user %s in channel %s, retry in %s",
+		decision.Scope, req.UserID, req.ChannelID, decision.RetryAfter.Round(time.Second))
+
+	// <t:unix:R> ditampilkan Discord sebagai waktu relatif, misal "dalam 12 detik";
+	// dibulatkan ke atas agar user tidak mencoba sebelum token tersedia
+	retryAt := time.Now().Add(decision.RetryAfter).Unix() + 1
+	var message string
+	switch decision.Scope {
+	case service.RateScopeChannel:
+		message = "⏳ Channel ini sedang ramai, command-nya dijeda sebentar. Coba lagi <t:%d:R>."
+	case service.RateScopeGuild:
+		message = "⏳ Server ini sudah mencapai batas pengambilan berita untuk sementara. Coba lagi <t:%d:R>."
+	default:
+		message = "⏳ Pelan-pelan ya, kamu terlalu sering memakai command. Coba lagi <t:%d:R>."
+	}
+	return response.TextMessage(fmt.Sprintf(message, retryAt)), decision.FirstDenial
+}
+
+// RunCommand menjalankan command berdasarkan nama slash command-nya
+func (u *MessageUsecase) RunCommand(ctx context.Context, name string, req CommandRequest) (*response.DiscordMessage, error) {
+	cmd, ok := u.commands.LookupSlash(name)
+	if !ok {
+		return u.unknownCommand(name, req.Args)
+	}
+	return u.runCommand(ctx, cmd, req)
+}
+
+// Commands mengembalikan definisi semua command, dipakai untuk registrasi slash command
+func (u *MessageUsecase) Commands() []*Command {
+	return u.commands.Commands()
+}
+
+// LookupCommand mencari definisi command berdasarkan nama slash command-nya
+func (u *MessageUsecase) LookupCommand(name string) (*Command, bool) {
+	return u.commands.LookupSlash(name)
+}
+
+func (u *MessageUsecase) runCommand(ctx context.Context, cmd *Command, req CommandRequest) (*response.DiscordMessage, error) {
+	if cmd.Handler == nil {
+		return response.TextMessage(fmt.Sprintf("ℹ️ Command ini hanya tersedia sebagai slash command `/%s`.", cmd.Name)), nil
+	}
+	if cmd.Arg != nil && cmd.Arg.Required && req.Args == "" {
+		return response.TextMessage(fmt.Sprintf("❌ Command `%s` butuh argumen `%s`.\n\n💡 Penggunaan: `%s`", cmd.Name, cmd.Arg.Name, cmd.Usage)), nil
+	}
+
+	reply, err := cmd.Handler(ctx, req)
+	var argErr *ArgError
+	if errors.As(err, &argErr) {
+		return response.TextMessage(fmt.Sprintf("❌ %s\n\n💡 Penggunaan: `%s`", argErr.Message, cmd.Usage)), nil
+	}
+	return reply, err
+}
+
+// unknownCommand menyarankan command yang mirip dengan name beserta tombol untuk
+// menjalankannya dengan args yang sama; tanpa kandidat, pesan bantuan umum yang dikirim
+func (u *MessageUsecase) unknownCommand(name, args string) (*response.DiscordMessage, error) {
+	suggestions := u.commands.Suggest(name, maxSuggestions)
+	if len(suggestions) == 0 {
 		resp := response.NewBotResponse("unknown").
 			WithDisplayText(u.getUnknownCommandMessage()).
 			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
+		return response.TextMessage(u.formatter.FormatBotResponse(resp)), nil
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("❓ **Command `%s` tidak dikenal**\n\n🤔 Mungkin maksud kamu:\n", name))
+
+	var buttons []discordgo.MessageComponent
+	for _, suggestion := range suggestions {
+		cmd := suggestion.Command
+		message.WriteString(fmt.Sprintf("• `/%s` - %s", cmd.Name, cmd.Description))
+		if suggestion.Match != cmd.Name {
+			message.WriteString(fmt.Sprintf(" (alias `%s`)", suggestion.Match))
+		}
+		message.WriteString("\n")
+
+		// Command yang butuh argumen hanya diberi tombol jika user sudah menulis argumennya
+		if cmd.SlashOnly || (cmd.Arg != nil && cmd.Arg.Required && args == "") {
+			continue
+		}
+		// Command tanpa argumen posisi hanya meneruskan flag, misal "!nwes --limit 3"
+		buttonArgs := args
+		if cmd.Arg == nil && (len(cmd.Flags) == 0 || !strings.HasPrefix(args, "--")) {
+			buttonArgs = ""
+		}
+		if button, ok := response.RunCommandButton(cmd.Name, buttonArgs); ok {
+			buttons = append(buttons, button)
+		}
+	}
+	message.WriteString("\n💡 Ketik `help` untuk melihat semua command")
+
+	reply := response.TextMessage(message.String())
+	if len(buttons) > 0 {
+		reply.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{Components: buttons},
+		}
 	}
+	return reply, nil
 }
 
-func (u *MessageUsecase) handleNewsRequest(ctx context.Context) (string, error) {
-	newsResponse, err := u.newsService.FetchTechNews(ctx)
+func (u *MessageUsecase) handleNewsRequest(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+	opts, err := parseSearchOptions(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if opts.Keyword != "" {
+		return nil, argErrorf("`news` tidak menerima kata kunci. Gunakan `/search %s` untuk mencari berita", opts.Keyword)
+	}
+
+	newsResponse, err := u.newsService.FetchTechNews(ctx, opts)
 	if err != nil {
 		log.Printf("Error fetching news: %v", err)
 
 		// Create error response
-		errorResp := response.NewErrorResponse("NEWS_FETCH_ERROR", "Failed to fetch tech news").
-			WithError("NEWS_FETCH_ERROR", "Maaf, terjadi kesalahan saat mengambil berita", err.Error()).
+		code, message := service.DescribeNewsError(err)
+		errorResp := response.NewErrorResponse(code, "Failed to fetch tech news").
+			WithError(code, message, "").
 			Build().(*response.BaseResponse)
 
-		return u.formatter.FormatBotResponse(&response.BotResponse{
+		return response.TextMessage(u.formatter.FormatBotResponse(&response.BotResponse{
 			BaseResponse: *errorResp,
 			Command:      "news",
-		}), err
+		})), err
 	}
 
 	if len(newsResponse.News) == 0 {
@@ -129,237 +295,115 @@ func (u *MessageUsecase) handleNewsRequest(ctx context.Context) (string, error)
 			WithMessage("No tech news available").
 			Build().(*response.NewsResponse)
 
-		return u.formatter.FormatNewsResponse(emptyResp), nil
+		return response.TextMessage(u.formatter.FormatNewsResponse(emptyResp)), nil
 	}
 
 	// Create successful news response
 	successResp := response.NewNewsResponse().
 		WithNews(newsResponse.News).
+		WithCacheInfo(newsResponse.CacheHit, newsResponse.ResponseTime).
 		WithMessage("Latest tech news").
 		Build().(*response.NewsResponse)
 
-	return u.formatter.FormatNewsResponse(successResp), nil
+	return u.renderer.RenderNewsResponse(successResp), nil
 }
 
-func (u *MessageUsecase) handleSearchRequest(ctx context.Context, keyword string) (string, error) {
+func (u *MessageUsecase) handleSearchRequest(ctx context.Context, req CommandRequest) (*response.DiscordMessage, error) {
+	opts, err := parseSearchOptions(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if opts.Keyword == "" {
+		return nil, argErrorf("Kata kunci pencarian belum diisi")
+	}
+
+	keyword := opts.Keyword
 	log.Printf("🔍 DEBUG: User searching for: %s", keyword)
 
 	// Call search function from news service
-	searchResults, err := u.newsService.SearchNews(ctx, keyword)
+	searchResults, err := u.newsService.SearchNews(ctx, opts)
 	if err != nil {
 		log.Printf("❌ ERROR: Search failed for '%s': %v", keyword, err)
 
 		// Create error response
+		code, message := service.DescribeNewsError(err)
 		errorResp := response.NewSearchResponse(keyword).
-			WithError("SEARCH_ERROR", "Pencarian gagal", err.Error()).
+			WithError(code, "Pencarian gagal", message).
 			Build().(*response.SearchResponse)
 
-		return u.formatter.FormatSearchResponse(errorResp), err
+		return response.TextMessage(u.formatter.FormatSearchResponse(errorResp)), err
 	}
 
 	// Create search response
 	searchResp := response.NewSearchResponse(keyword).
-		WithSearchResults(searchResults, len(searchResults)).
+		WithSearchResults(searchResults.News, len(searchResults.News)).
+		WithCacheInfo(searchResults.CacheHit, searchResults.ResponseTime).
 		WithMessage("Search completed successfully").
 		Build().(*response.SearchResponse)
 
-	return u.formatter.FormatSearchResponse(searchResp), nil
-}
-
-func (u *MessageUsecase) handleCronStatusRequest(ctx context.Context) (string, error) {
-	// Get the server URL from environment or use default
-	serverURL := os.Getenv("SERVER_URL")
-	if serverURL == "" {
-		serverURL = "http://localhost:8080" // Default local server
-	}
-
-	// Make HTTP request to /health/cron endpoint
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(serverURL + "/health/cron")
-	if err != nil {
-		log.Printf("❌ ERROR: Failed to fetch cron status: %v", err)
-
-		// Create error response
-		errorResp := response.NewBotResponse("cron").
-			WithDisplayText("❌ **Error**: Tidak dapat mengakses status cron jobs\n\n" +
-				"🔧 **Possible Issues:**\n" +
-				"• Server tidak berjalan\n" +
-				"• Koneksi network bermasalah\n" +
-				"• Endpoint `/health/cron` tidak tersedia").
-			Build().(*response.BotResponse)
-
-		return u.formatter.FormatBotResponse(errorResp), err
+	if len(searchResp.Results) == 0 {
+		return response.TextMessage(u.formatter.FormatSearchResponse(searchResp)), nil
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Printf("❌ ERROR: Failed to read response body: %v", err)
-
-		errorResp := response.NewBotResponse("cron").
-			WithDisplayText("❌ **Error**: Gagal membaca response dari server").
-			Build().(*response.BotResponse)
+	return u.renderer.RenderSearchResponse(searchResp), nil
+}
 
-		return u.formatter.FormatBotResponse(errorResp), err
+func schedulerStateEmoji(state string) string {
+	switch state {
+	case service.SchedulerRunning:
+		return "🔥"
+	case service.SchedulerNotLeader:
+		return "💤"
 	}
+	return "🛑"
+}
 
-	// Parse JSON response
-	var cronData map[string]interface{}
-	if err := json.Unmarshal(body, &cronData); err != nil {
-		log.Printf("❌ ERROR: Failed to parse JSON: %v", err)
-
-		errorResp := response.NewBotResponse("cron").
-			WithDisplayText("❌ **Error**: Gagal memparse response JSON dari server").
-			Build().(*response.BotResponse)
-
-		return u.formatter.FormatBotResponse(errorResp), err
-	}
+// handleCronStatusRequest menampilkan status job dan riwayat run langsung dari scheduler
+func (u *MessageUsecase) handleCronStatusRequest(ctx context.Context) (*response.DiscordMessage, error) {
+	location := u.cron.DefaultLocation()
+	statuses := u.cron.JobStatuses()
 
 	// Build the response message
 	var message strings.Builder
 	message.WriteString("📅 **Cron Jobs Status**\n\n")
-
-	// Status
-	if status, ok := cronData["status"].(string); ok {
-		message.WriteString(fmt.Sprintf("🔥 **Status**: %s\n\n", status))
-	}
+	state := u.cron.State()
+	message.WriteString(fmt.Sprintf("%s **Status**: %s • %d job aktif\n\n", schedulerStateEmoji(state), state, len(statuses)))
 
 	// Cron Jobs
-	if cronJobs, ok := cronData["cron_jobs"].(map[string]interface{}); ok {
+	if len(statuses) > 0 {
 		message.WriteString("⏰ **Scheduled Jobs:**\n")
-		for jobName, schedule := range cronJobs {
-			message.WriteString(fmt.Sprintf("• **%s**: %s\n", jobName, schedule))
+		for _, status := range statuses {
+			message.WriteString(fmt.Sprintf("• **%s** `%s` (%s): berikutnya %s",
+				status.Name, status.CronExpr, status.Location, status.NextRun.In(status.Location).Format("02 Jan 15:04 MST")))
+			if status.LastResult != nil {
+				message.WriteString(" • terakhir " + status.LastResult.Summary())
+			}
+			message.WriteString("\n")
 		}
 		message.WriteString("\n")
 	}
 
-	// Timezone
-	if timezone, ok := cronData["timezone"].(string); ok {
-		message.WriteString(fmt.Sprintf("🌍 **Timezone**: %s\n", timezone))
+	// Riwayat run terakhir
+	if recentRuns := u.cron.RunHistory("", 5); len(recentRuns) > 0 {
+		message.WriteString("🧾 **Riwayat Run Terakhir:**\n")
+		for _, run := range recentRuns {
+			message.WriteString(fmt.Sprintf("• **%s** %s: %s\n", run.Name, run.StartedAt.In(location).Format("02 Jan 15:04 MST"), run.Summary()))
+		}
+		message.WriteString("\n")
 	}
 
-	// Last Check
-	if lastCheck, ok := cronData["last_check"].(string); ok {
-		message.WriteString(fmt.Sprintf("🕐 **Last Check**: %s\n", lastCheck))
-	}
+	// Timezone
+	message.WriteString(fmt.Sprintf("🌍 **Timezone**: %s (server: %s)\n", location, time.Local))
 
-	message.WriteString("\n💡 **Info**: Data diambil dari endpoint `/health/cron`")
+	// Last Check
+	message.WriteString(fmt.Sprintf("🕐 **Last Check**: %s\n", time.Now().In(location).Format("2006-01-02 15:04:05 MST")))
 
 	// Create successful response
 	successResp := response.NewBotResponse("cron").
 		WithDisplayText(message.String()).
 		Build().(*response.BotResponse)
 
-	return u.formatter.FormatBotResponse(successResp), nil
-}
-
-// ProcessMessageWithContext processes a message with user and channel context
-func (u *MessageUsecase) ProcessMessageWithContext(ctx context.Context, content, userID, username, channelID, channelName string) (string, error) {
-	content = strings.TrimSpace(content)
-	originalCommand := strings.ToLower(content)
-
-	botPrefixes := []string{"/", "!"}
-	hasPrefix := false
-	command := originalCommand
-
-	for _, prefix := range botPrefixes {
-		if strings.HasPrefix(originalCommand, prefix) {
-			command = strings.TrimPrefix(originalCommand, prefix)
-			hasPrefix = true
-			break
-		}
-	}
-
-	// If no prefix found, ignore the message
-	if !hasPrefix {
-		return "", nil // Return empty string to indicate message should be ignored
-	}
-
-	switch command {
-	case "news", "berita", "tech", "teknologi":
-		return u.handleNewsRequest(ctx)
-	case "hello", "hi", "halo":
-		resp := response.NewBotResponse("hello").
-			WithDisplayText("Hello! 👋 Saya adalah **AI Tech News Bot**\n\n🤖 Saya bisa membantu Anda mendapatkan berita teknologi terbaru!\n\n💡 Ketik `help` untuk melihat command yang tersedia.").
-			WithUserInfo(userID, username, false).
-			WithChannelInfo(channelID, channelName, "text").
-			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
-	case "help", "bantuan":
-		resp := response.NewBotResponse("help").
-			WithUserInfo(userID, username, false).
-			WithChannelInfo(channelID, channelName, "text").
-			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
-	case "ping":
-		resp := response.NewBotResponse("ping").
-			WithDisplayText("🏓 Pong! Bot sedang online dan siap melayani!").
-			WithUserInfo(userID, username, false).
-			WithChannelInfo(channelID, channelName, "text").
-			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
-	case "status":
-		services := map[string]string{
-			"News API": "Ready",
-			"Discord":  "Connected",
-		}
-		resp := response.NewStatusResponse().
-			WithStatus("Online dan berjalan normal").
-			WithServices(services).
-			Build().(*response.StatusResponse)
-		return u.formatter.FormatStatusResponse(resp), nil
-	case "cron", "schedule", "jadwal":
-		return u.handleCronStatusRequest(ctx)
-	default:
-		// Check if it's a search command
-		if strings.HasPrefix(command, "search ") || strings.HasPrefix(command, "cari ") {
-			keyword := strings.TrimPrefix(command, "search ")
-			keyword = strings.TrimPrefix(keyword, "cari ")
-			keyword = strings.TrimSpace(keyword)
-			if keyword != "" {
-				return u.handleSearchRequest(ctx, keyword)
-			}
-		}
-		resp := response.NewBotResponse("unknown").
-			WithDisplayText(u.getUnknownCommandMessage()).
-			WithUserInfo(userID, username, false).
-			WithChannelInfo(channelID, channelName, "text").
-			Build().(*response.BotResponse)
-		return u.formatter.FormatBotResponse(resp), nil
-	}
-}
-
-func (u *MessageUsecase) getHelpMessage() string {
-	return `📋 **AI Tech News Bot - Command List**
-
-🔥 **Main Commands:**
-• ` + "`/news`" + ` atau ` + "`!berita`" + ` - Dapatkan berita teknologi terbaru
-• ` + "`/hello`" + ` atau ` + "`!hi`" + ` - Sapa bot
-• ` + "`/help`" + ` atau ` + "`!bantuan`" + ` - Tampilkan menu ini
-• ` + "`/ping`" + ` - Cek status koneksi bot
-• ` + "`/status`" + ` - Lihat status bot
-• ` + "`/cron`" + ` atau ` + "`!jadwal`" + ` - Lihat status cron jobs
-
-🔍 **Search Commands**:
-• ` + "`/search <keyword>`" + ` - Cari berita berdasarkan kata kunci
-• ` + "`!cari <keyword>`" + ` - Pencarian dalam bahasa Indonesia
-
-📝 **Contoh Penggunaan:**
-• ` + "`/search AI`" + ` - Cari berita tentang AI
-• ` + "`!cari blockchain`" + ` - Cari berita blockchain
-• ` + "`/search startup`" + ` - Cari berita startup  
-• ` + "`/cron`" + ` - Cek jadwal cron jobs
-
-💡 **Tips**: Gunakan prefix ` + "`/`" + ` atau ` + "`!`" + ` di awal command
-
----
-🤖 **About**: Saya adalah bot yang menyediakan berita teknologi terbaru dari berbagai sumber terpercaya.
-📡 **Sources**: Hacker News dan sumber terpercaya lainnya.
-⚡ **Update**: Real-time news feed`
+	return response.TextMessage(u.formatter.FormatBotResponse(successResp)), nil
 }
 
 func (u *MessageUsecase) getUnknownCommandMessage() string {
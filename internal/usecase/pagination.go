@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"discord-ai-tech-news/internal/response"
+)
+
+// PaginationStore menyimpan state halaman per message ID di memori.
+// Sesi yang tidak dipakai lebih lama dari ttl dianggap kedaluwarsa.
+type PaginationStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sessions map[string]*pageSession
+}
+
+type pageSession struct {
+	pages     *response.PageSet
+	expiresAt time.Time
+}
+
+func NewPaginationStore(ttl time.Duration) *PaginationStore {
+	return &PaginationStore{
+		ttl:      ttl,
+		sessions: make(map[string]*pageSession),
+	}
+}
+
+// Save menyimpan PageSet untuk pesan yang baru terkirim
+func (p *PaginationStore) Save(messageID string, pages *response.PageSet) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sweep()
+	p.sessions[messageID] = &pageSession{
+		pages:     pages,
+		expiresAt: time.Now().Add(p.ttl),
+	}
+}
+
+// Turn memindahkan halaman sebanyak delta dan memperpanjang masa berlaku sesi.
+// Halaman dibatasi di dalam lock dan yang dikembalikan adalah salinan, jadi klik
+// tombol yang bersamaan tidak saling menimpa saat dirender.
+// Mengembalikan false jika sesi tidak ada atau sudah kedaluwarsa.
+func (p *PaginationStore) Turn(messageID string, delta int) (*response.PageSet, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sweep()
+	session, ok := p.sessions[messageID]
+	if !ok {
+		return nil, false
+	}
+
+	session.pages.Page = session.pages.ClampPage(session.pages.Page + delta)
+	session.expiresAt = time.Now().Add(p.ttl)
+
+	pages := *session.pages
+	return &pages, true
+}
+
+// sweep membuang sesi yang sudah kedaluwarsa; dipanggil dengan mu terkunci
+func (p *PaginationStore) sweep() {
+	now := time.Now()
+	for messageID, session := range p.sessions {
+		if now.After(session.expiresAt) {
+			delete(p.sessions, messageID)
+		}
+	}
+}
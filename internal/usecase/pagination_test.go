@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"discord-ai-tech-news/internal/response"
+)
+
+func testPageSet(items int) *response.PageSet {
+	return &response.PageSet{Items: make([]response.NewsItem, items)}
+}
+
+func TestPaginationTurnClampsPage(t *testing.T) {
+	store := NewPaginationStore(time.Minute)
+	store.Save("msg", testPageSet(12)) // 3 halaman
+
+	tests := []struct {
+		delta int
+		want  int
+	}{
+		{1, 1},
+		{1, 2},
+		{1, 2},
+		{-5, 0},
+		{-1, 0},
+		{1, 1},
+	}
+	for i, tt := range tests {
+		pages, ok := store.Turn("msg", tt.delta)
+		if !ok {
+			t.Fatalf("step %d: session missing", i)
+		}
+		if pages.Page != tt.want {
+			t.Errorf("step %d: Turn(%d) page = %d, want %d", i, tt.delta, pages.Page, tt.want)
+		}
+	}
+}
+
+func TestPaginationTurnReturnsCopy(t *testing.T) {
+	store := NewPaginationStore(time.Minute)
+	store.Save("msg", testPageSet(12))
+
+	first, _ := store.Turn("msg", 1)
+	first.Page = 99
+
+	second, _ := store.Turn("msg", 1)
+	if second.Page != 2 {
+		t.Errorf("page = %d, want 2: mutating a returned PageSet must not affect the session", second.Page)
+	}
+}
+
+func TestPaginationTurnExpired(t *testing.T) {
+	store := NewPaginationStore(time.Millisecond)
+	store.Save("msg", testPageSet(12))
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := store.Turn("msg", 1); ok {
+		t.Error("expired session should not turn")
+	}
+	if _, ok := store.Turn("unknown", 1); ok {
+		t.Error("unknown message should not turn")
+	}
+}
+
+func TestPaginationConcurrentTurns(t *testing.T) {
+	store := NewPaginationStore(time.Minute)
+	store.Save("msg", testPageSet(50)) // 10 halaman
+	renderer := response.NewEmbedRenderer()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(delta int) {
+			defer wg.Done()
+			if pages, ok := store.Turn("msg", delta); ok {
+				renderer.RenderPage(pages)
+			}
+		}(1 - 2*(i%2))
+	}
+	wg.Wait()
+
+	pages, _ := store.Turn("msg", 0)
+	if pages.Page < 0 || pages.Page >= pages.PageCount() {
+		t.Errorf("page %d out of range after concurrent turns", pages.Page)
+	}
+}
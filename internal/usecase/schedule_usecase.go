@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+	"discord-ai-tech-news/internal/service"
+)
+
+// Aksi untuk command /schedule
+const (
+	ScheduleActionAdd    = "add"
+	ScheduleActionRemove = "remove"
+	ScheduleActionList   = "list"
+	ScheduleActionPause  = "pause"
+	ScheduleActionResume = "resume"
+)
+
+// ScheduleCommand adalah input /schedule dari Discord. ID dipakai oleh
+// remove/pause/resume, field lainnya oleh add.
+type ScheduleCommand struct {
+	Action    string
+	ID        string
+	Name      string
+	CronExpr  string
+	Timezone  string
+	ChannelID string
+	Header    string
+	Query     string
+	Kind      string
+}
+
+// ManageSchedule menjalankan aksi /schedule untuk sebuah guild. Admin guild hanya
+// bisa mengubah jadwal milik guild-nya sendiri; jadwal global (tanpa guild)
+// dikelola lewat HTTP admin API dan diaktifkan per guild lewat /config schedules.
+func (u *MessageUsecase) ManageSchedule(guildID string, cmd ScheduleCommand) (*response.DiscordMessage, error) {
+	if guildID == "" {
+		return response.TextMessage("❌ Command `/schedule` hanya bisa dipakai di dalam server."), nil
+	}
+
+	if cmd.Action == ScheduleActionList {
+		return u.listSchedules(guildID)
+	}
+
+	if cmd.Action == ScheduleActionAdd {
+		schedule, err := u.cron.AddSchedule(repository.Schedule{
+			Name:      cmd.Name,
+			CronExpr:  cmd.CronExpr,
+			Timezone:  cmd.Timezone,
+			GuildID:   guildID,
+			ChannelID: cmd.ChannelID,
+			Header:    cmd.Header,
+			Query:     cmd.Query,
+			Kind:      cmd.Kind,
+		})
+		if err != nil {
+			return scheduleErrorMessage(cmd.Action, err)
+		}
+		return response.TextMessage("✅ **Jadwal ditambahkan**\n\n" + formatSchedule(*schedule)), nil
+	}
+
+	schedule, err := u.cron.GetSchedule(cmd.ID)
+	if err != nil {
+		return scheduleErrorMessage(cmd.Action, err)
+	}
+	if schedule.GuildID != guildID {
+		return response.TextMessage(fmt.Sprintf("⛔ Jadwal `%s` bukan milik server ini.", cmd.ID)), nil
+	}
+
+	var title string
+	switch cmd.Action {
+	case ScheduleActionRemove:
+		schedule, err = u.cron.RemoveSchedule(cmd.ID)
+		title = "🗑️ **Jadwal dihapus**"
+	case ScheduleActionPause:
+		schedule, err = u.cron.PauseSchedule(cmd.ID)
+		title = "⏸️ **Jadwal di-pause**"
+	case ScheduleActionResume:
+		schedule, err = u.cron.ResumeSchedule(cmd.ID)
+		title = "▶️ **Jadwal dilanjutkan**"
+	default:
+		return response.TextMessage(fmt.Sprintf("❓ Aksi schedule `%s` tidak dikenal.", cmd.Action)), nil
+	}
+	if err != nil {
+		return scheduleErrorMessage(cmd.Action, err)
+	}
+
+	return response.TextMessage(title + "\n\n" + formatSchedule(*schedule)), nil
+}
+
+// listSchedules menampilkan jadwal global dan jadwal milik guild ini
+func (u *MessageUsecase) listSchedules(guildID string) (*response.DiscordMessage, error) {
+	schedules, err := u.cron.ListSchedules()
+	if err != nil {
+		return scheduleErrorMessage(ScheduleActionList, err)
+	}
+
+	var message strings.Builder
+	message.WriteString("📅 **Jadwal Berita Otomatis**\n\n")
+
+	count := 0
+	for _, schedule := range schedules {
+		if schedule.GuildID != "" && schedule.GuildID != guildID {
+			continue
+		}
+		message.WriteString(formatSchedule(schedule) + "\n")
+		count++
+	}
+
+	if count == 0 {
+		message.WriteString("Belum ada jadwal. Tambahkan dengan `/schedule add`.")
+	}
+
+	return response.TextMessage(message.String()), nil
+}
+
+func scheduleErrorMessage(action string, err error) (*response.DiscordMessage, error) {
+	switch {
+	case errors.Is(err, repository.ErrScheduleNotFound):
+		return response.TextMessage("❓ Jadwal dengan ID tersebut tidak ditemukan. Cek ID-nya lewat `/schedule list`."), nil
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return response.TextMessage(fmt.Sprintf("❌ **Jadwal tidak valid**: %v\n\n💡 Format cron: `menit jam tanggal bulan hari`, misal `0 8 * * 1-5`. Zona waktu memakai nama IANA, misal `Asia/Jakarta`.", err)), nil
+	case errors.Is(err, repository.ErrNotLeader):
+		return notLeaderMessage(), nil
+	}
+
+	log.Printf("❌ ERROR: Failed to %s schedule: %v", action, err)
+	return response.TextMessage("❌ **Error**: Gagal menyimpan jadwal."), err
+}
+
+// notLeaderMessage menjelaskan bahwa perubahan ditolak karena replica ini bukan leader.
+// State tiap replica terpisah, jadi perubahan di sini tidak akan dipakai job leader.
+func notLeaderMessage() *response.DiscordMessage {
+	return response.TextMessage("⚠️ Instance bot ini bukan leader, jadi perubahan tidak disimpan. Coba lagi beberapa saat lagi.")
+}
+
+func formatSchedule(schedule repository.Schedule) string {
+	status := "🟢"
+	if schedule.Paused {
+		status = "⏸️"
+	}
+
+	target := "news channel tiap server"
+	if schedule.ChannelID != "" {
+		target = "<#" + schedule.ChannelID + ">"
+	}
+
+	timezone := schedule.Timezone
+	if timezone == "" {
+		timezone = "zona waktu guild/default"
+	}
+
+	line := fmt.Sprintf("%s `%s` **%s** • `%s` (%s) • %s", status, schedule.ID, schedule.Name, schedule.CronExpr, timezone, target)
+	if schedule.Query != "" {
+		line += fmt.Sprintf(" • 🔍 %s", schedule.Query)
+	}
+	if schedule.Kind != repository.ScheduleKindNews {
+		line += fmt.Sprintf(" • 🗞️ %s", schedule.Kind)
+	}
+	return line
+}
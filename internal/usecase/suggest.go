@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions adalah jumlah saran "mungkin maksud kamu" untuk command tidak dikenal
+const maxSuggestions = 3
+
+// CommandSuggestion adalah command yang namanya mirip dengan input user
+type CommandSuggestion struct {
+	Command *Command
+	// Match adalah nama atau alias yang paling mirip, misal "cari" untuk "!car"
+	Match    string
+	Distance int
+}
+
+// Suggest mencari command yang nama atau aliasnya (Inggris maupun Indonesia) paling
+// mirip dengan input berdasarkan edit distance. Satu command muncul sekali dengan
+// kecocokan terbaiknya; hasil diurutkan dari yang paling mirip lalu urutan registrasi.
+func (r *CommandRegistry) Suggest(input string, limit int) []CommandSuggestion {
+	input = strings.ToLower(input)
+	if input == "" {
+		return nil
+	}
+	maxDistance := suggestDistance(input)
+
+	best := make(map[*Command]CommandSuggestion)
+	consider := func(cmd *Command, name string) {
+		distance := editDistance(input, name)
+		if distance > maxDistance {
+			return
+		}
+		if current, ok := best[cmd]; !ok || distance < current.Distance {
+			best[cmd] = CommandSuggestion{Command: cmd, Match: name, Distance: distance}
+		}
+	}
+
+	order := make(map[*Command]int)
+	for i, cmd := range r.commands {
+		order[cmd] = i
+		consider(cmd, cmd.Name)
+		for _, alias := range cmd.Aliases {
+			consider(cmd, alias)
+		}
+	}
+
+	suggestions := make([]CommandSuggestion, 0, len(best))
+	for _, suggestion := range best {
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Distance != suggestions[j].Distance {
+			return suggestions[i].Distance < suggestions[j].Distance
+		}
+		return order[suggestions[i].Command] < order[suggestions[j].Command]
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// suggestDistance adalah edit distance maksimal yang masih dianggap typo;
+// input pendek hanya boleh beda satu huruf agar saran tetap relevan
+func suggestDistance(input string) int {
+	if len([]rune(input)) <= 3 {
+		return 1
+	}
+	return 2
+}
+
+// editDistance menghitung jarak Levenshtein dengan transposisi dua huruf bersebelahan
+// dihitung satu langkah (optimal string alignment), jadi "serach" berjarak 1 dari "search"
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
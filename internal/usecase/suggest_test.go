@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"discord-ai-tech-news/internal/response"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"search", "search", 0},
+		{"serach", "search", 1}, // transposisi dihitung satu langkah
+		{"car", "cari", 1},
+		{"nwes", "news", 1},
+		{"hlep", "help", 1},
+		{"berta", "berita", 1},
+		{"xyz", "news", 4},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestMatchesNamesAndAliases(t *testing.T) {
+	u := NewMessageUsecase(nil, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		input       string
+		wantCommand string
+		wantMatch   string
+	}{
+		{"serach", "search", "search"},
+		{"CAR", "search", "cari"},
+		{"bantuann", "help", "bantuan"},
+		{"teknolgi", "news", "teknologi"},
+	}
+	for _, tt := range tests {
+		suggestions := u.commands.Suggest(tt.input, maxSuggestions)
+		if len(suggestions) == 0 {
+			t.Errorf("Suggest(%q) returned nothing", tt.input)
+			continue
+		}
+		if best := suggestions[0]; best.Command.Name != tt.wantCommand || best.Match != tt.wantMatch {
+			t.Errorf("Suggest(%q) = /%s via %q, want /%s via %q", tt.input, best.Command.Name, best.Match, tt.wantCommand, tt.wantMatch)
+		}
+	}
+
+	if suggestions := u.commands.Suggest("zzzzzz", maxSuggestions); len(suggestions) != 0 {
+		t.Errorf("Suggest(zzzzzz) = %+v, want none", suggestions)
+	}
+}
+
+func TestUnknownCommandOffersRunnableButtons(t *testing.T) {
+	u := NewMessageUsecase(nil, nil, nil, nil, nil, nil, nil)
+
+	reply, err := u.ProcessMessage(context.Background(), "!serach rust --limit 3", CommandRequest{})
+	if err != nil {
+		t.Fatalf("ProcessMessage: %v", err)
+	}
+	if !strings.Contains(reply.Content, "Mungkin maksud kamu") {
+		t.Fatalf("reply does not suggest anything:\n%s", reply.Content)
+	}
+	if got := buttonIDs(reply); len(got) == 0 || got[0] != response.RunCommandButtonPrefix+"search rust --limit 3" {
+		t.Errorf("buttons = %q, want search with the original args first", got)
+	}
+
+	// Command dengan argumen wajib tidak diberi tombol tanpa argumen
+	reply, err = u.ProcessMessage(context.Background(), "!serach", CommandRequest{})
+	if err != nil {
+		t.Fatalf("ProcessMessage: %v", err)
+	}
+	for _, id := range buttonIDs(reply) {
+		if strings.HasPrefix(id, response.RunCommandButtonPrefix+"search") {
+			t.Errorf("search without a keyword should not get a button, got %q", id)
+		}
+	}
+}
+
+func buttonIDs(reply *response.DiscordMessage) []string {
+	var ids []string
+	for _, component := range reply.Components {
+		row, ok := component.(discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, button := range row.Components {
+			if button, ok := button.(discordgo.Button); ok {
+				ids = append(ids, button.CustomID)
+			}
+		}
+	}
+	return ids
+}
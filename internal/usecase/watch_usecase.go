@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"discord-ai-tech-news/internal/repository"
+	"discord-ai-tech-news/internal/response"
+	"discord-ai-tech-news/internal/service"
+)
+
+// Watch menambah kata kunci ke watchlist user; artikel baru yang cocok dikirim lewat DM
+func (u *MessageUsecase) Watch(userID, term string) (*response.DiscordMessage, error) {
+	// Polling watchlist hanya berjalan di leader
+	if err := u.cron.RequireLeader(); err != nil {
+		log.Printf("⚠️ Rejected /watch for user %s: %v", userID, err)
+		return notLeaderMessage(), nil
+	}
+
+	term, added, err := u.watch.Watch(userID, term)
+	switch {
+	case errors.Is(err, service.ErrInvalidWatchTerm):
+		return response.TextMessage("❌ Kata kunci harus 2-50 karakter, misal `/watch kubernetes`."), nil
+	case errors.Is(err, repository.ErrWatchLimit):
+		return response.TextMessage(fmt.Sprintf("❌ Watchlist sudah berisi %d kata kunci. Hapus salah satu dengan `/unwatch` dulu.", service.MaxWatchTerms)), nil
+	case err != nil:
+		log.Printf("❌ ERROR: Failed to add watch %q for user %s: %v", term, userID, err)
+		return response.TextMessage("❌ **Error**: Gagal menyimpan watchlist."), err
+	case !added:
+		return response.TextMessage(fmt.Sprintf("ℹ️ `%s` sudah ada di watchlist kamu.", term)), nil
+	}
+
+	return response.TextMessage(fmt.Sprintf("🔔 **Watch aktif**: `%s`\n\nArtikel baru yang cocok akan dikirim lewat DM. Pastikan DM dari member server tidak dinonaktifkan.", term)), nil
+}
+
+// Unwatch menghapus kata kunci dari watchlist user
+func (u *MessageUsecase) Unwatch(userID, term string) (*response.DiscordMessage, error) {
+	if err := u.cron.RequireLeader(); err != nil {
+		log.Printf("⚠️ Rejected /unwatch for user %s: %v", userID, err)
+		return notLeaderMessage(), nil
+	}
+
+	term, removed, err := u.watch.Unwatch(userID, term)
+	if err != nil {
+		log.Printf("❌ ERROR: Failed to remove watch %q for user %s: %v", term, userID, err)
+		return response.TextMessage("❌ **Error**: Gagal menyimpan watchlist."), err
+	}
+	if !removed {
+		return response.TextMessage(fmt.Sprintf("❓ `%s` tidak ada di watchlist kamu. Cek dengan `/watchlist`.", term)), nil
+	}
+
+	return response.TextMessage(fmt.Sprintf("🔕 **Watch dihapus**: `%s`", term)), nil
+}
+
+// Watchlist menampilkan kata kunci yang dipantau user
+func (u *MessageUsecase) Watchlist(userID string) (*response.DiscordMessage, error) {
+	terms, err := u.watch.List(userID)
+	if err != nil {
+		log.Printf("❌ ERROR: Failed to load watchlist for user %s: %v", userID, err)
+		return response.TextMessage("❌ **Error**: Gagal memuat watchlist."), err
+	}
+
+	if len(terms) == 0 {
+		return response.TextMessage("📭 Watchlist kamu masih kosong. Tambahkan kata kunci dengan `/watch kubernetes`."), nil
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "🔔 **Watchlist Kamu** (%d/%d)\n\n", len(terms), service.MaxWatchTerms)
+	for _, term := range terms {
+		fmt.Fprintf(&message, "• `%s` • sejak %s\n", term.Term, term.CreatedAt.Format("2 Jan 2006"))
+	}
+	message.WriteString("\n💡 *Hapus dengan `/unwatch`*")
+
+	return response.TextMessage(message.String()), nil
+}